@@ -1,16 +1,21 @@
 package config
 
 import (
+	"context"
 	"os"
 
 	"github.com/caarlos0/env/v6"
 	"github.com/joho/godotenv"
-	log "github.com/sirupsen/logrus"
 
 	"github.com/tarmalonchik/golibs/trace"
 )
 
-func Load(conf interface{}, configFile string) error {
+func Load(conf interface{}, configFile string, opts ...Opt) error {
+	o := newDefaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	configs := make([]string, 0, 2)
 
 	path := "./configs/.env"
@@ -23,11 +28,26 @@ func Load(conf interface{}, configFile string) error {
 	}
 
 	if err := godotenv.Load(configs...); err != nil {
-		log.WithField("filenames", configs).Info("config file not found, using defaults")
+		o.log.WithField("filenames", configs).Info("config file not found, using defaults")
+	}
+
+	var envOpts []env.Options
+	if len(o.secrets) > 0 {
+		values := environ()
+		if _, err := resolveSecrets(context.Background(), conf, values, o.secrets); err != nil {
+			return trace.FuncNameWithError(err)
+		}
+		envOpts = append(envOpts, env.Options{Environment: values})
 	}
 
-	if err := env.Parse(conf); err != nil {
+	if err := env.Parse(conf, envOpts...); err != nil {
 		return trace.FuncNameWithErrorMsg(err, "env config process")
 	}
+
+	if o.validate {
+		if err := Validate(conf); err != nil {
+			return trace.FuncNameWithError(err)
+		}
+	}
 	return nil
 }
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/tarmalonchik/golibs/trace"
+)
+
+const descTag = "desc"
+
+// Variable describes the environment variable of the config.
+type Variable struct {
+	Name        string
+	Type        string
+	Default     string
+	HasDefault  bool
+	Required    bool
+	Rules       string
+	Description string
+}
+
+// Describe walks conf including nested structs with envPrefix and returns its variables in order of fields.
+// The description is taken from desc tag:
+//
+//	PgSSLMode string `env:"POSTGRES_SSL_MODE" envDefault:"verify-full" desc:"SSL mode of the connection"`
+func Describe(conf interface{}) []Variable {
+	t := reflect.TypeOf(conf)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var out []Variable
+	describeStruct(t, "", &out)
+	return out
+}
+
+func describeStruct(t reflect.Type, prefix string, out *[]Variable) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if isNested(field) {
+			describeStruct(field.Type, prefix+field.Tag.Get("envPrefix"), out)
+			continue
+		}
+
+		key, opts, _ := strings.Cut(field.Tag.Get("env"), ",")
+		if key == "" {
+			continue
+		}
+
+		rules := field.Tag.Get(validateTag)
+		def, hasDefault := field.Tag.Lookup("envDefault")
+
+		*out = append(*out, Variable{
+			Name:        prefix + key,
+			Type:        field.Type.String(),
+			Default:     def,
+			HasDefault:  hasDefault,
+			Required:    slices.Contains(strings.Split(opts, ","), "required") || slices.Contains(splitRules(rules), "required"),
+			Rules:       rules,
+			Description: field.Tag.Get(descTag),
+		})
+	}
+}
+
+// Markdown writes the table of the config variables.
+func Markdown(w io.Writer, conf interface{}) error {
+	var sb strings.Builder
+	sb.WriteString("| Variable | Type | Default | Required | Rules | Description |\n")
+	sb.WriteString("|---|---|---|---|---|---|\n")
+
+	for _, v := range Describe(conf) {
+		def := ""
+		if v.HasDefault {
+			def = "`" + v.Default + "`"
+		}
+		required := ""
+		if v.Required {
+			required = "yes"
+		}
+
+		fmt.Fprintf(&sb, "| `%s` | %s | %s | %s | %s | %s |\n",
+			v.Name, mdEscape(v.Type), mdEscape(def), required, mdEscape(v.Rules), mdEscape(v.Description))
+	}
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return trace.FuncNameWithErrorMsg(err, "write markdown")
+	}
+	return nil
+}
+
+// ExampleEnv writes the example .env file, the optional variables without default are commented out.
+func ExampleEnv(w io.Writer, conf interface{}) error {
+	var sb strings.Builder
+	for i, v := range Describe(conf) {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+
+		var notes []string
+		if v.Description != "" {
+			notes = append(notes, v.Description)
+		}
+		if v.Required {
+			notes = append(notes, "required")
+		}
+		if v.Rules != "" {
+			notes = append(notes, "rules: "+v.Rules)
+		}
+		if len(notes) > 0 {
+			sb.WriteString("# " + strings.Join(notes, ", ") + "\n")
+		}
+
+		if !v.Required && !v.HasDefault {
+			sb.WriteString("# ")
+		}
+		sb.WriteString(v.Name + "=" + envQuote(v.Default) + "\n")
+	}
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return trace.FuncNameWithErrorMsg(err, "write example env")
+	}
+	return nil
+}
+
+func mdEscape(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+func envQuote(s string) string {
+	if strings.ContainsAny(s, " #\"'") {
+		return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+	}
+	return s
+}
+
+// WriteDocs writes Markdown and ExampleEnv of conf to the files, it is meant for a small generator of the service:
+//
+//	//go:generate go run ./cmd/configdocs
+//	func main() {
+//		if err := config.WriteDocs(app.Config{}, "docs/config.md", "configs/.env.example"); err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+func WriteDocs(conf interface{}, markdownPath, envPath string) error {
+	var md, env strings.Builder
+	if err := Markdown(&md, conf); err != nil {
+		return trace.FuncNameWithError(err)
+	}
+	if err := ExampleEnv(&env, conf); err != nil {
+		return trace.FuncNameWithError(err)
+	}
+
+	if err := os.WriteFile(markdownPath, []byte(md.String()), 0o644); err != nil {
+		return trace.FuncNameWithErrorMsg(err, "write markdown")
+	}
+	if err := os.WriteFile(envPath, []byte(env.String()), 0o644); err != nil {
+		return trace.FuncNameWithErrorMsg(err, "write example env")
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type docsDB struct {
+	Address string `env:"ADDRESS" envDefault:"localhost" desc:"host of the server"`
+	Pass    string `env:"PASS,required" desc:"password | secret"`
+}
+
+type docsConfig struct {
+	DB    docsDB  `envPrefix:"DB_"`
+	Token string  `env:"TOKEN" validate:"min=10"`
+	Hook  url.URL `env:"HOOK_URL" desc:"webhook"`
+}
+
+func TestDocs(t *testing.T) {
+	var md strings.Builder
+	require.NoError(t, Markdown(&md, docsConfig{}))
+	require.Equal(t, "| Variable | Type | Default | Required | Rules | Description |\n"+
+		"|---|---|---|---|---|---|\n"+
+		"| `DB_ADDRESS` | string | `localhost` |  |  | host of the server |\n"+
+		"| `DB_PASS` | string |  | yes |  | password \\| secret |\n"+
+		"| `TOKEN` | string |  |  | min=10 |  |\n"+
+		"| `HOOK_URL` | url.URL |  |  |  | webhook |\n", md.String())
+
+	var env strings.Builder
+	require.NoError(t, ExampleEnv(&env, &docsConfig{}))
+	require.Equal(t, "# host of the server\nDB_ADDRESS=localhost\n\n"+
+		"# password | secret, required\nDB_PASS=\n\n"+
+		"# rules: min=10\n# TOKEN=\n\n"+
+		"# webhook\n# HOOK_URL=\n", env.String())
+}
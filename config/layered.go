@@ -0,0 +1,136 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/caarlos0/env/v6"
+
+	"github.com/tarmalonchik/golibs/trace"
+)
+
+const (
+	sourceDefault = "default"
+	sourceUnset   = "unset"
+)
+
+// Origin describes where the final value of the key came from.
+type Origin struct {
+	Key    string
+	Value  string
+	Source string
+}
+
+// Provenance holds the origins of all keys of the config sorted by key.
+type Provenance []Origin
+
+// Get returns the origin of the key.
+func (p Provenance) Get(key string) (Origin, bool) {
+	idx := slices.IndexFunc(p, func(o Origin) bool { return o.Key == key })
+	if idx < 0 {
+		return Origin{}, false
+	}
+	return p[idx], true
+}
+
+// Dump writes the table of keys, values and sources, the values of secret-like keys are masked.
+func (p Provenance) Dump(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, o := range p {
+		value := o.Value
+		if isSecretKey(o.Key) && value != "" {
+			value = "[masked]"
+		}
+		if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\n", o.Key, value, o.Source); err != nil {
+			return trace.FuncNameWithErrorMsg(err, "write provenance")
+		}
+	}
+	return tw.Flush()
+}
+
+func isSecretKey(key string) bool {
+	key = strings.ToUpper(key)
+	for _, part := range []string{"PASS", "SECRET", "TOKEN", "KEY"} {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadLayered fills conf from the sources, the later source overrides the earlier one and
+// envDefault tags are used when no source has the key. The usual order is
+//
+//	config.LoadLayered(&conf, []config.Source{
+//		config.Defaults(defaults),
+//		config.OptionalFile("./configs/config.yaml"),
+//		config.OptionalFile("./configs/.env"),
+//		config.OptionalFile("./configs/local.env"),
+//		config.Environment(),
+//		config.Flags(os.Args[1:]),
+//	})
+//
+// The returned provenance tells where every value came from, the secrets are shown as references.
+func LoadLayered(conf interface{}, sources []Source, opts ...Opt) (Provenance, error) {
+	o := newDefaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	values := make(map[string]string)
+	from := make(map[string]string)
+
+	for _, s := range sources {
+		items, err := s.Values()
+		if err != nil {
+			return nil, trace.FuncNameWithErrorMsg(err, "load "+s.Name())
+		}
+		for key, value := range items {
+			values[key] = value
+			from[key] = s.Name()
+		}
+	}
+
+	refs := maps.Clone(values)
+	secrets, err := resolveSecrets(context.Background(), conf, values, o.secrets)
+	if err != nil {
+		return nil, trace.FuncNameWithError(err)
+	}
+
+	var out Provenance
+	err = env.Parse(conf, env.Options{
+		Environment: values,
+		OnSet: func(key string, value interface{}, isDefault bool) {
+			origin := Origin{Key: key, Value: fmt.Sprint(value), Source: from[key]}
+			if scheme, ok := secrets[key]; ok {
+				origin.Value = refs[key]
+				origin.Source += " (" + scheme + " secret)"
+			}
+
+			switch {
+			case isDefault:
+				origin.Source = sourceDefault
+			case origin.Source == "":
+				origin.Source = sourceUnset
+			}
+			out = append(out, origin)
+		},
+	})
+	if err != nil {
+		return nil, trace.FuncNameWithErrorMsg(err, "env config process")
+	}
+
+	if o.validate {
+		if err = Validate(conf); err != nil {
+			return nil, trace.FuncNameWithError(err)
+		}
+	}
+
+	slices.SortFunc(out, func(a, b Origin) int { return strings.Compare(a.Key, b.Key) })
+	return out, nil
+}
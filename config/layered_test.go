@@ -0,0 +1,179 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testPostgres struct {
+	SSLMode  string        `env:"SSL_MODE" envDefault:"verify-full"`
+	Port     int           `env:"PORT" envDefault:"5432"`
+	Timeout  time.Duration `env:"TIMEOUT" envDefault:"5s"`
+	Password string        `env:"PASS"`
+	Hosts    []string      `env:"HOSTS"`
+}
+
+type testConfig struct {
+	Postgres testPostgres `envPrefix:"POSTGRES_"`
+	Debug    bool         `env:"DEBUG"`
+	Name     string       `env:"NAME"`
+}
+
+func TestLoadLayered(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+		return path
+	}
+
+	yamlFile := write("config.yaml", "postgres:\n  ssl_mode: disable\n  port: 6432\n  hosts: [a, b]\nname: yaml\n")
+	tomlFile := write("config.toml", "name = \"to\\\"#ml\" # comment\npostgres = { timeout = \"10s\" }\n")
+	envFile := write(".env", "POSTGRES_PASS=secret\nPOSTGRES_PORT=7432\n")
+	t.Setenv("POSTGRES_SSL_MODE", "require")
+
+	var conf testConfig
+	prov, err := LoadLayered(&conf, []Source{
+		Defaults(map[string]string{"NAME": "default"}),
+		File(yamlFile),
+		File(tomlFile),
+		File(envFile),
+		OptionalFile(filepath.Join(dir, "missing.json")),
+		Environment(),
+		Flags([]string{"--postgres-port=8432", "--debug"}),
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, testConfig{
+		Postgres: testPostgres{
+			SSLMode:  "require",
+			Port:     8432,
+			Timeout:  10 * time.Second,
+			Password: "secret",
+			Hosts:    []string{"a", "b"},
+		},
+		Debug: true,
+		Name:  `to"#ml`,
+	}, conf)
+
+	origin, ok := prov.Get("POSTGRES_SSL_MODE")
+	require.True(t, ok)
+	require.Equal(t, "env", origin.Source)
+
+	origin, _ = prov.Get("POSTGRES_TIMEOUT")
+	require.Equal(t, "file:"+tomlFile, origin.Source)
+
+	var sb strings.Builder
+	require.NoError(t, prov.Dump(&sb))
+	require.Contains(t, sb.String(), "POSTGRES_PASS")
+	require.NotContains(t, sb.String(), "secret")
+
+	_, err = File(write("bad.toml", "name = toml\n")).Values()
+	require.Error(t, err)
+}
+
+func TestFlags(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		args []string
+		want map[string]string
+	}{
+		{
+			name: "value after equal sign",
+			args: []string{"--postgres-ssl-mode=disable", "--name=a=b"},
+			want: map[string]string{"POSTGRES_SSL_MODE": "disable", "NAME": "a=b"},
+		},
+		{
+			name: "bare flag does not take the next argument",
+			args: []string{"--debug", "serve", "--name=api"},
+			want: map[string]string{"DEBUG": "true", "NAME": "api"},
+		},
+		{
+			name: "arguments after double dash",
+			args: []string{"--debug=false", "--", "--name=api"},
+			want: map[string]string{"DEBUG": "false"},
+		},
+		{
+			name: "short flags and positional arguments",
+			args: []string{"-v", "serve", "--"},
+			want: map[string]string{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			values, err := Flags(tc.args).Values()
+			require.NoError(t, err)
+			require.Equal(t, tc.want, values)
+		})
+	}
+}
+
+func TestLoadLayeredSecrets(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "pg_pass")
+	require.NoError(t, os.WriteFile(secretFile, []byte("from-file\n"), 0o600))
+
+	var conf testConfig
+	prov, err := LoadLayered(&conf, []Source{Defaults(map[string]string{
+		"POSTGRES_PASS":  "file://" + secretFile,
+		"NAME":           "vault://app/name#value",
+		"POSTGRES_HOSTS": "https://a,https://b",
+		"OTHER_TOOL_URL": "file:///does/not/exist",
+	})}, WithSecretProviders(FileSecrets(), MemorySecrets("vault", map[string]string{"app/name#value": "from-vault"})))
+	require.NoError(t, err)
+
+	require.Equal(t, "from-file", conf.Postgres.Password)
+	require.Equal(t, "from-vault", conf.Name)
+	require.Equal(t, []string{"https://a", "https://b"}, conf.Postgres.Hosts)
+
+	origin, _ := prov.Get("NAME")
+	require.Equal(t, Origin{Key: "NAME", Value: "vault://app/name#value", Source: "defaults (vault secret)"}, origin)
+
+	_, err = LoadLayered(&conf, []Source{Defaults(map[string]string{"NAME": "vault://missing#key"})},
+		WithSecretProviders(MemorySecrets("vault", nil)))
+	require.ErrorIs(t, err, ErrSecretNotFound)
+}
+
+type validatedConfig struct {
+	URL     string        `env:"URL" validate:"url"`
+	Address string        `env:"ADDRESS" validate:"required_without=URL,hostport"`
+	Mode    string        `env:"MODE" envDefault:"fast" validate:"oneof=fast slow"`
+	Workers int           `env:"WORKERS" envDefault:"0" validate:"min=1,max=64"`
+	Timeout time.Duration `env:"TIMEOUT" envDefault:"1ms" validate:"min=1s"`
+	Name    string        `env:"NAME" envDefault:"a,b" validate:"regex=^[a-z]+(,[a-z]+)*$"`
+}
+
+func TestValidate(t *testing.T) {
+	var conf validatedConfig
+	_, err := LoadLayered(&conf, []Source{Defaults(map[string]string{"MODE": "medium"})})
+	require.NoError(t, err, "validation is opt-in")
+
+	_, err = LoadLayered(&conf, []Source{Defaults(map[string]string{"MODE": "medium"})}, WithValidation())
+
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	require.Equal(t, []string{
+		"ADDRESS: required when URL is empty",
+		"MODE: should be one of fast, slow",
+		"WORKERS: should be at least 1",
+		"TIMEOUT: should be at least 1s",
+	}, violationMessages(verr))
+
+	_, err = LoadLayered(&conf, []Source{Defaults(map[string]string{
+		"ADDRESS": "localhost:5432",
+		"WORKERS": "4",
+		"TIMEOUT": "2s",
+	})}, WithValidation())
+	require.NoError(t, err)
+}
+
+func violationMessages(err *ValidationError) []string {
+	out := make([]string, 0, len(err.Violations))
+	for _, v := range err.Violations {
+		out = append(out, v.Error())
+	}
+	return out
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/tarmalonchik/golibs/logger"
+)
+
+type Opt func(o *options)
+
+type options struct {
+	log      logrus.FieldLogger
+	secrets  map[string]SecretProvider
+	validate bool
+}
+
+func newDefaultOptions() *options {
+	return &options{
+		log:     logrus.StandardLogger(),
+		secrets: make(map[string]SecretProvider),
+	}
+}
+
+// WithLogger makes Load log through the logger instead of the standard logrus logger.
+func WithLogger(l *logger.Logger) Opt {
+	return func(o *options) {
+		o.log = l.Logrus()
+	}
+}
+
+// WithSecretProviders resolves the values which are references to secrets, e.g. file:///run/secrets/pg_pass,
+// the values with other schemes are left as is.
+func WithSecretProviders(providers ...SecretProvider) Opt {
+	return func(o *options) {
+		for _, p := range providers {
+			o.secrets[p.Scheme()] = p
+		}
+	}
+}
+
+// WithValidation checks the loaded config with Validate, see it for the rules of validate tags.
+func WithValidation() Opt {
+	return func(o *options) {
+		o.validate = true
+	}
+}
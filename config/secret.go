@@ -0,0 +1,118 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tarmalonchik/golibs/trace"
+)
+
+var ErrSecretNotFound = errors.New("secret not found")
+
+// SecretProvider resolves the values like vault://path#key, the provider is chosen by the scheme of the value.
+type SecretProvider interface {
+	Scheme() string
+	Resolve(ctx context.Context, ref *url.URL) (string, error)
+}
+
+type fileSecrets struct{}
+
+// FileSecrets resolves file:///run/secrets/pg_pass to the content of the file without trailing new line.
+func FileSecrets() SecretProvider {
+	return fileSecrets{}
+}
+
+func (fileSecrets) Scheme() string {
+	return "file"
+}
+
+func (fileSecrets) Resolve(_ context.Context, ref *url.URL) (string, error) {
+	// file://relative/path is allowed too
+	path := filepath.FromSlash(ref.Host + ref.Path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", trace.FuncNameWithErrorMsg(err, "read secret file")
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+type memorySecrets struct {
+	scheme  string
+	secrets map[string]string
+}
+
+// MemorySecrets resolves the values of the scheme from the map keyed by the reference without scheme,
+// e.g. MemorySecrets("vault", map[string]string{"db/postgres#password": "secret"}). It is useful in tests.
+func MemorySecrets(scheme string, secrets map[string]string) SecretProvider {
+	return &memorySecrets{scheme: scheme, secrets: secrets}
+}
+
+func (m *memorySecrets) Scheme() string {
+	return m.scheme
+}
+
+func (m *memorySecrets) Resolve(_ context.Context, ref *url.URL) (string, error) {
+	key := strings.TrimPrefix(ref.String(), m.scheme+"://")
+	value, ok := m.secrets[key]
+	if !ok {
+		return "", fmt.Errorf("%s: %w", key, ErrSecretNotFound)
+	}
+	return value, nil
+}
+
+// resolveSecrets replaces the references in values of the variables read by conf and returns the schemes
+// of the resolved keys. The other values are left as is, even if they look like a reference.
+func resolveSecrets(
+	ctx context.Context, conf interface{}, values map[string]string, providers map[string]SecretProvider,
+) (map[string]string, error) {
+	if len(providers) == 0 {
+		return nil, nil
+	}
+
+	var (
+		resolved = make(map[string]string)
+		errs     []error
+	)
+	for _, v := range Describe(conf) {
+		key := v.Name
+		value, ok := values[key]
+		if !ok {
+			continue
+		}
+
+		scheme, _, ok := strings.Cut(value, "://")
+		if !ok {
+			continue
+		}
+		provider, ok := providers[scheme]
+		if !ok {
+			continue
+		}
+
+		ref, err := url.Parse(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("parse secret reference of %s: %w", key, err))
+			continue
+		}
+
+		secret, err := provider.Resolve(ctx, ref)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("resolve secret of %s: %w", key, err))
+			continue
+		}
+
+		values[key] = secret
+		resolved[key] = scheme
+	}
+
+	if len(errs) > 0 {
+		return nil, trace.FuncNameWithError(errors.Join(errs...))
+	}
+	return resolved, nil
+}
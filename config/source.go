@@ -0,0 +1,185 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+
+	"github.com/tarmalonchik/golibs/trace"
+)
+
+// Source provides the values keyed by the full environment names, e.g. POSTGRES_SSL_MODE.
+type Source interface {
+	Name() string
+	Values() (map[string]string, error)
+}
+
+type source struct {
+	name   string
+	path   string // set for file sources, the watcher polls it
+	values func() (map[string]string, error)
+}
+
+func (s source) Name() string {
+	return s.name
+}
+
+func (s source) filePath() string {
+	return s.path
+}
+
+func (s source) Values() (map[string]string, error) {
+	return s.values()
+}
+
+// Defaults provides the values set in code, they override envDefault tags.
+func Defaults(values map[string]string) Source {
+	return source{name: "defaults", values: func() (map[string]string, error) {
+		return values, nil
+	}}
+}
+
+// Environment provides the environment variables of the process.
+func Environment() Source {
+	return source{name: "env", values: func() (map[string]string, error) {
+		return environ(), nil
+	}}
+}
+
+func environ() map[string]string {
+	out := make(map[string]string)
+	for _, item := range os.Environ() {
+		if key, value, ok := strings.Cut(item, "="); ok {
+			out[key] = value
+		}
+	}
+	return out
+}
+
+// Flags provides the command line flags, --postgres-ssl-mode=disable sets POSTGRES_SSL_MODE,
+// the flag without value is set to true. The arguments after -- are not parsed.
+func Flags(args []string) Source {
+	return source{name: "flags", values: func() (map[string]string, error) {
+		out := make(map[string]string)
+		for _, arg := range args {
+			if arg == "--" {
+				break
+			}
+			arg, ok := strings.CutPrefix(arg, "--")
+			if !ok || arg == "" {
+				continue
+			}
+
+			key, value, hasValue := strings.Cut(arg, "=")
+			if !hasValue {
+				value = "true"
+			}
+			out[normalizeKey(key)] = value
+		}
+		return out, nil
+	}}
+}
+
+// File provides the values of the file, the format is chosen by extension: .yaml, .yml, .json, .toml or .env.
+// Nested keys are joined with underscore, so postgres: {ssl_mode: disable} sets POSTGRES_SSL_MODE.
+func File(path string) Source {
+	return fileSource(path, false)
+}
+
+// OptionalFile is File which provides nothing when the file does not exist.
+func OptionalFile(path string) Source {
+	return fileSource(path, true)
+}
+
+func fileSource(path string, optional bool) Source {
+	return source{name: "file:" + path, path: path, values: func() (map[string]string, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if optional && errors.Is(err, os.ErrNotExist) {
+				return nil, nil
+			}
+			return nil, trace.FuncNameWithErrorMsg(err, "read file")
+		}
+
+		out, err := parseFile(path, data)
+		if err != nil {
+			return nil, trace.FuncNameWithErrorMsg(err, "parse "+path)
+		}
+		return out, nil
+	}}
+}
+
+func parseFile(path string, data []byte) (map[string]string, error) {
+	var tree map[string]any
+
+	switch ext := strings.ToLower(filepath.Ext(path)); {
+	case ext == ".yaml" || ext == ".yml":
+		if err := yaml.Unmarshal(data, &tree); err != nil {
+			return nil, trace.FuncNameWithErrorMsg(err, "unmarshal yaml")
+		}
+	case ext == ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		if err := dec.Decode(&tree); err != nil {
+			return nil, trace.FuncNameWithErrorMsg(err, "unmarshal json")
+		}
+	case ext == ".toml":
+		if err := toml.Unmarshal(data, &tree); err != nil {
+			return nil, trace.FuncNameWithErrorMsg(err, "unmarshal toml")
+		}
+	case ext == ".env" || strings.HasPrefix(filepath.Base(path), ".env"):
+		out, err := godotenv.UnmarshalBytes(data)
+		if err != nil {
+			return nil, trace.FuncNameWithErrorMsg(err, "unmarshal env")
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("unknown config format of %s", path)
+	}
+
+	out := make(map[string]string)
+	flatten(out, "", tree)
+	return out, nil
+}
+
+func flatten(out map[string]string, prefix string, value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			flatten(out, joinKey(prefix, key), item)
+		}
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+		out[prefix] = strings.Join(items, ",")
+	case nil:
+		out[prefix] = ""
+	case time.Time:
+		out[prefix] = v.Format(time.RFC3339Nano)
+	default:
+		out[prefix] = fmt.Sprint(v)
+	}
+}
+
+func joinKey(prefix, key string) string {
+	key = normalizeKey(key)
+	if prefix == "" {
+		return key
+	}
+	return prefix + "_" + key
+}
+
+func normalizeKey(key string) string {
+	return strings.NewReplacer("-", "_", ".", "_").Replace(strings.ToUpper(key))
+}
@@ -0,0 +1,286 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const validateTag = "validate"
+
+// Validator is implemented by configs which check themselves after loading, e.g. cross-field rules
+// which could not be expressed with tags.
+type Validator interface {
+	Validate() error
+}
+
+// Violation is the broken rule of the field, the field is named by its full env key when it has one.
+type Violation struct {
+	Field string
+	Rule  string
+	Err   error
+}
+
+func (v Violation) Error() string {
+	return fmt.Sprintf("%s: %s", v.Field, v.Err)
+}
+
+// ValidationError holds all violations of the config.
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	items := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		items = append(items, v.Error())
+	}
+	return "invalid config: " + strings.Join(items, "; ")
+}
+
+// Validate checks the validate tags of conf and its nested structs and calls Validator of the structs,
+// all violations are returned at once as *ValidationError. The rules are separated by commas:
+//
+//	min=1, max=65535           range of numbers, length of strings and slices, bounds of durations (min=1s)
+//	oneof=disable require      allowed values separated by spaces
+//	required                   the value should not be empty
+//	url, hostport, port        format of the value
+//	required_without=PgURL     the value is required when the other field of the struct is empty
+//	required_with=PgUser       the value is required when the other field of the struct is set
+//	regex=^[a-z]+$             should be the last rule, the pattern could contain commas
+//
+// The rules except required ones are skipped for empty strings.
+func Validate(conf interface{}) error {
+	v := reflect.ValueOf(conf)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var violations []Violation
+	validateStruct(v, "", &violations)
+
+	if len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+	return nil
+}
+
+func validateStruct(v reflect.Value, prefix string, violations *[]Violation) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		value := v.Field(i)
+
+		if isNested(field) {
+			validateStruct(value, prefix+field.Tag.Get("envPrefix"), violations)
+			continue
+		}
+
+		tag, ok := field.Tag.Lookup(validateTag)
+		if !ok {
+			continue
+		}
+
+		name := fieldName(field, prefix)
+		for _, rule := range splitRules(tag) {
+			if err := checkRule(v, value, rule); err != nil {
+				*violations = append(*violations, Violation{Field: name, Rule: rule, Err: err})
+			}
+		}
+	}
+
+	if validator, ok := addr(v).(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			*violations = append(*violations, Violation{Field: structName(v, prefix), Rule: "validator", Err: err})
+		}
+	}
+}
+
+// isNested reports whether the fields of the struct field are read by env, the struct with env tag
+// is a single value, e.g. url.URL.
+func isNested(field reflect.StructField) bool {
+	if field.Type.Kind() != reflect.Struct {
+		return false
+	}
+	_, hasPrefix := field.Tag.Lookup("envPrefix")
+	return hasPrefix || field.Tag.Get("env") == ""
+}
+
+func addr(v reflect.Value) interface{} {
+	if v.CanAddr() {
+		return v.Addr().Interface()
+	}
+	return v.Interface()
+}
+
+func fieldName(field reflect.StructField, prefix string) string {
+	key, _, _ := strings.Cut(field.Tag.Get("env"), ",")
+	if key == "" {
+		return prefix + field.Name
+	}
+	return prefix + key
+}
+
+func structName(v reflect.Value, prefix string) string {
+	if prefix != "" {
+		return strings.TrimSuffix(prefix, "_")
+	}
+	return v.Type().Name()
+}
+
+func splitRules(tag string) []string {
+	var out []string
+	for tag != "" {
+		if strings.HasPrefix(tag, "regex=") {
+			return append(out, tag)
+		}
+
+		rule, rest, _ := strings.Cut(tag, ",")
+		if rule = strings.TrimSpace(rule); rule != "" {
+			out = append(out, rule)
+		}
+		tag = strings.TrimSpace(rest)
+	}
+	return out
+}
+
+func checkRule(parent, value reflect.Value, rule string) error {
+	name, arg, _ := strings.Cut(rule, "=")
+
+	switch name {
+	case "required":
+		if value.IsZero() {
+			return errors.New("required")
+		}
+		return nil
+	case "required_without":
+		other, err := sibling(parent, arg)
+		if err != nil {
+			return err
+		}
+		if other.IsZero() && value.IsZero() {
+			return fmt.Errorf("required when %s is empty", arg)
+		}
+		return nil
+	case "required_with":
+		other, err := sibling(parent, arg)
+		if err != nil {
+			return err
+		}
+		if !other.IsZero() && value.IsZero() {
+			return fmt.Errorf("required when %s is set", arg)
+		}
+		return nil
+	}
+
+	if value.Kind() == reflect.String && value.String() == "" {
+		return nil
+	}
+
+	switch name {
+	case "min", "max":
+		return checkBound(value, name, arg)
+	case "oneof":
+		allowed := strings.Fields(arg)
+		if !slices.Contains(allowed, fmt.Sprint(value.Interface())) {
+			return fmt.Errorf("should be one of %s", strings.Join(allowed, ", "))
+		}
+	case "url":
+		u, err := url.Parse(value.String())
+		if err != nil || u.Scheme == "" || (u.Host == "" && u.Opaque == "") {
+			return errors.New("should be absolute url")
+		}
+	case "hostport":
+		host, port, err := net.SplitHostPort(value.String())
+		if err != nil || host == "" {
+			return errors.New("should be host:port")
+		}
+		return checkPort(port)
+	case "port":
+		return checkPort(value.String())
+	case "regex":
+		re, err := regexp.Compile(arg)
+		if err != nil {
+			return fmt.Errorf("invalid regex: %w", err)
+		}
+		if !re.MatchString(value.String()) {
+			return fmt.Errorf("should match %s", arg)
+		}
+	default:
+		return fmt.Errorf("unknown rule %s", name)
+	}
+	return nil
+}
+
+func sibling(parent reflect.Value, name string) (reflect.Value, error) {
+	other := parent.FieldByName(name)
+	if !other.IsValid() {
+		return reflect.Value{}, fmt.Errorf("unknown field %s", name)
+	}
+	return other, nil
+}
+
+func checkPort(port string) error {
+	p, err := strconv.Atoi(port)
+	if err != nil || p < 1 || p > 65535 {
+		return errors.New("should be port in range 1-65535")
+	}
+	return nil
+}
+
+func checkBound(value reflect.Value, name, arg string) error {
+	var (
+		actual, bound float64
+		err           error
+	)
+
+	switch {
+	case value.Type() == reflect.TypeOf(time.Duration(0)):
+		var d time.Duration
+		if d, err = time.ParseDuration(arg); err != nil {
+			return fmt.Errorf("invalid duration bound %s", arg)
+		}
+		actual, bound = float64(value.Int()), float64(d)
+	case value.CanInt():
+		actual = float64(value.Int())
+		bound, err = strconv.ParseFloat(arg, 64)
+	case value.CanUint():
+		actual = float64(value.Uint())
+		bound, err = strconv.ParseFloat(arg, 64)
+	case value.CanFloat():
+		actual = value.Float()
+		bound, err = strconv.ParseFloat(arg, 64)
+	case value.Kind() == reflect.String || value.Kind() == reflect.Slice || value.Kind() == reflect.Map:
+		actual = float64(value.Len())
+		bound, err = strconv.ParseFloat(arg, 64)
+	default:
+		return fmt.Errorf("%s is not supported by %s", name, value.Type())
+	}
+	if err != nil {
+		return fmt.Errorf("invalid bound %s", arg)
+	}
+
+	if name == "min" && actual < bound {
+		return fmt.Errorf("should be at least %s", arg)
+	}
+	if name == "max" && actual > bound {
+		return fmt.Errorf("should be at most %s", arg)
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package config
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/tarmalonchik/golibs/trace"
+)
+
+// SubscribeFunc is called with the previous and the new config after every applied change.
+type SubscribeFunc[T any] func(old, new *T)
+
+type watchOptions struct {
+	interval time.Duration
+	onError  func(err error)
+	loadOpts []Opt
+}
+
+type WatchOpt func(o *watchOptions)
+
+// WithPollInterval sets how often the file sources are checked for changes, 5 seconds by default.
+func WithPollInterval(interval time.Duration) WatchOpt {
+	return func(o *watchOptions) {
+		if interval > 0 {
+			o.interval = interval
+		}
+	}
+}
+
+// WithReloadErrorHandler is called when the changed sources could not be loaded or validated by Run.
+func WithReloadErrorHandler(fn func(err error)) WatchOpt {
+	return func(o *watchOptions) {
+		o.onError = fn
+	}
+}
+
+// WithLoadOptions passes the options to LoadLayered, e.g. WithSecretProviders.
+func WithLoadOptions(opts ...Opt) WatchOpt {
+	return func(o *watchOptions) {
+		o.loadOpts = append(o.loadOpts, opts...)
+	}
+}
+
+// Watcher keeps the config loaded from the sources up to date. The changes of the file sources are
+// detected by Run, Reload re-reads the sources on demand, e.g. as launcher reload hook.
+type Watcher[T any] struct {
+	sources []Source
+	o       watchOptions
+
+	reloadMu sync.Mutex
+	stamps   map[string]fileStamp
+
+	mu      sync.RWMutex
+	current *T
+	prov    Provenance
+	subs    map[int]SubscribeFunc[T]
+	nextSub int
+}
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+	exists  bool
+}
+
+// NewWatcher loads the config from the sources, see LoadLayered for the precedence.
+func NewWatcher[T any](sources []Source, opts ...WatchOpt) (*Watcher[T], error) {
+	w := &Watcher[T]{
+		sources: sources,
+		o: watchOptions{
+			interval: 5 * time.Second,
+			onError:  func(error) {},
+		},
+		subs: make(map[int]SubscribeFunc[T]),
+	}
+	for _, opt := range opts {
+		opt(&w.o)
+	}
+
+	w.stamps = w.fileStamps()
+
+	conf, prov, err := w.load()
+	if err != nil {
+		return nil, trace.FuncNameWithError(err)
+	}
+	w.current, w.prov = conf, prov
+
+	return w, nil
+}
+
+// Get returns the current config, it must not be modified.
+func (w *Watcher[T]) Get() *T {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return w.current
+}
+
+// Provenance returns the origins of the current config values.
+func (w *Watcher[T]) Provenance() Provenance {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return w.prov
+}
+
+// Subscribe adds fn called on every change, the returned func removes it.
+func (w *Watcher[T]) Subscribe(fn SubscribeFunc[T]) (unsubscribe func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	id := w.nextSub
+	w.nextSub++
+	w.subs[id] = fn
+
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.subs, id)
+	}
+}
+
+// Reload re-reads the sources, validates the config when WithValidation is passed with WithLoadOptions
+// and notifies the subscribers if it is changed.
+func (w *Watcher[T]) Reload(_ context.Context) error {
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+
+	w.stamps = w.fileStamps()
+	return w.reloadLocked()
+}
+
+// Run polls the file sources and reloads the config when they change until ctx is done.
+func (w *Watcher[T]) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.o.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := w.poll(); err != nil {
+				w.o.onError(err)
+			}
+		}
+	}
+}
+
+func (w *Watcher[T]) poll() error {
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+
+	stamps := w.fileStamps()
+	if reflect.DeepEqual(stamps, w.stamps) {
+		return nil
+	}
+	// the stamps are updated even if the reload fails, so the broken file is not reloaded on every tick
+	w.stamps = stamps
+
+	return w.reloadLocked()
+}
+
+func (w *Watcher[T]) reloadLocked() error {
+	conf, prov, err := w.load()
+	if err != nil {
+		return trace.FuncNameWithError(err)
+	}
+
+	w.mu.Lock()
+	old := w.current
+	if reflect.DeepEqual(old, conf) {
+		w.prov = prov
+		w.mu.Unlock()
+		return nil
+	}
+	w.current, w.prov = conf, prov
+
+	subs := make([]SubscribeFunc[T], 0, len(w.subs))
+	for _, fn := range w.subs {
+		subs = append(subs, fn)
+	}
+	w.mu.Unlock()
+
+	for _, fn := range subs {
+		fn(old, conf)
+	}
+	return nil
+}
+
+func (w *Watcher[T]) load() (*T, Provenance, error) {
+	conf := new(T)
+
+	prov, err := LoadLayered(conf, w.sources, w.o.loadOpts...)
+	if err != nil {
+		return nil, nil, trace.FuncNameWithError(err)
+	}
+	return conf, prov, nil
+}
+
+func (w *Watcher[T]) fileStamps() map[string]fileStamp {
+	out := make(map[string]fileStamp)
+	for _, s := range w.sources {
+		fs, ok := s.(interface{ filePath() string })
+		if !ok || fs.filePath() == "" {
+			continue
+		}
+
+		info, err := os.Stat(fs.filePath())
+		if err != nil {
+			out[fs.filePath()] = fileStamp{}
+			continue
+		}
+		out[fs.filePath()] = fileStamp{modTime: info.ModTime(), size: info.Size(), exists: true}
+	}
+	return out
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type reloadConfig struct {
+	LogLevel string `env:"LOG_LEVEL" envDefault:"info"`
+	Retries  int    `env:"RETRIES" envDefault:"3"`
+}
+
+func (c *reloadConfig) Validate() error {
+	if c.Retries < 0 {
+		return errors.New("retries should not be negative")
+	}
+	return nil
+}
+
+func TestWatcher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.env")
+	require.NoError(t, os.WriteFile(path, []byte("LOG_LEVEL=debug\n"), 0o600))
+
+	reloadErrs := make(chan error, 1)
+	w, err := NewWatcher[reloadConfig]([]Source{File(path)},
+		WithPollInterval(10*time.Millisecond),
+		WithReloadErrorHandler(func(err error) { reloadErrs <- err }),
+		WithLoadOptions(WithValidation()),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "debug", w.Get().LogLevel)
+
+	changes := make(chan [2]reloadConfig, 1)
+	w.Subscribe(func(old, new *reloadConfig) {
+		changes <- [2]reloadConfig{*old, *new}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = w.Run(ctx) }()
+
+	require.NoError(t, os.WriteFile(path, []byte("LOG_LEVEL=error\nRETRIES=5\n"), 0o600))
+	select {
+	case change := <-changes:
+		require.Equal(t, reloadConfig{LogLevel: "debug", Retries: 3}, change[0])
+		require.Equal(t, reloadConfig{LogLevel: "error", Retries: 5}, change[1])
+	case <-time.After(5 * time.Second):
+		t.Fatal("change is not detected")
+	}
+
+	require.NoError(t, os.WriteFile(path, []byte("RETRIES=-1\n"), 0o600))
+	select {
+	case err = <-reloadErrs:
+		require.ErrorContains(t, err, "retries should not be negative")
+	case <-time.After(5 * time.Second):
+		t.Fatal("invalid config is not reported")
+	}
+	require.Equal(t, 5, w.Get().Retries)
+}
@@ -13,12 +13,17 @@ func NewConnection(addr string, opts ...Opt) (*grpc.ClientConn, error) {
 		opts[i](conf)
 	}
 
+	loggingInterceptor := interceptor.NewLoggingClientInterceptor(conf.logLevel)
+	if conf.logger != nil {
+		loggingInterceptor = interceptor.NewLoggerClientInterceptor(conf.logger)
+	}
+
 	return grpc.NewClient(
 		addr,
 		grpc.WithTransportCredentials(conf.credentials),
 		grpc.WithChainUnaryInterceptor(
 			timeout.UnaryClientInterceptor(conf.timeout),
-			interceptor.NewLoggingClientInterceptor(conf.logLevel),
+			loggingInterceptor,
 			retry.UnaryClientInterceptor(conf.retry...),
 		),
 	)
@@ -7,6 +7,7 @@ import (
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/retry"
 	"github.com/sirupsen/logrus"
 	"github.com/tarmalonchik/golibs/grpc"
+	"github.com/tarmalonchik/golibs/logger"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
@@ -16,6 +17,7 @@ type Opt func(*options)
 
 type options struct {
 	logLevel        logrus.Level
+	logger          *logger.Logger
 	retry           []retry.CallOption
 	timeout         time.Duration
 	perRetryTimeout time.Duration
@@ -37,6 +39,13 @@ func WithLogLevel(lvl grpc.LogLevel) Opt {
 	}
 }
 
+// WithLogger logs the calls through the logger instead of logrus, WithLogLevel is ignored then.
+func WithLogger(l *logger.Logger) Opt {
+	return func(v *options) {
+		v.logger = l
+	}
+}
+
 func WithRetryMax(maxRetries uint) Opt {
 	return func(v *options) {
 		v.retry = append(v.retry, retry.WithMax(maxRetries))
@@ -3,10 +3,13 @@ package interceptor
 import (
 	"context"
 	"fmt"
+	"log/slog"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+
+	"github.com/tarmalonchik/golibs/logger"
 )
 
 func NewLoggingServerInterceptor(level logrus.Level) grpc.UnaryServerInterceptor {
@@ -17,12 +20,29 @@ func NewLoggingClientInterceptor(level logrus.Level) grpc.UnaryClientInterceptor
 	return logging.UnaryClientInterceptor(wrapLogrus(logrusWithLevel(level)))
 }
 
+// NewLoggerServerInterceptor logs the calls through the logger, its level decides which calls are logged.
+func NewLoggerServerInterceptor(l *logger.Logger) grpc.UnaryServerInterceptor {
+	return logging.UnaryServerInterceptor(wrapSlog(slog.New(l.SlogHandler())))
+}
+
+// NewLoggerClientInterceptor logs the calls through the logger, its level decides which calls are logged.
+func NewLoggerClientInterceptor(l *logger.Logger) grpc.UnaryClientInterceptor {
+	return logging.UnaryClientInterceptor(wrapSlog(slog.New(l.SlogHandler())))
+}
+
 func logrusWithLevel(level logrus.Level) *logrus.Logger {
 	l := logrus.New()
 	l.SetLevel(level)
 	return l
 }
 
+// wrapSlog passes the level as is, the levels of the logging package match slog ones.
+func wrapSlog(l *slog.Logger) logging.Logger {
+	return logging.LoggerFunc(func(ctx context.Context, lvl logging.Level, msg string, fields ...any) {
+		l.Log(ctx, slog.Level(lvl), msg, fields...)
+	})
+}
+
 func wrapLogrus(l logrus.FieldLogger) logging.Logger {
 	return logging.LoggerFunc(func(_ context.Context, lvl logging.Level, msg string, fields ...any) {
 		f := make(map[string]any, len(fields)/2)
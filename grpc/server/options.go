@@ -4,6 +4,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/tarmalonchik/golibs/grpc"
 	"github.com/tarmalonchik/golibs/grpc/interceptor"
+	"github.com/tarmalonchik/golibs/logger"
 	grpc2 "google.golang.org/grpc"
 )
 
@@ -11,6 +12,7 @@ type Opt func(*options)
 
 type options struct {
 	logLevel     logrus.Level
+	logger       *logger.Logger
 	auth         interceptor.Auth
 	interceptors []grpc2.UnaryServerInterceptor
 }
@@ -27,6 +29,13 @@ func WithLogLevel(lvl grpc.LogLevel) Opt {
 	}
 }
 
+// WithLogger logs the calls through the logger instead of logrus, WithLogLevel is ignored then.
+func WithLogger(l *logger.Logger) Opt {
+	return func(v *options) {
+		v.logger = l
+	}
+}
+
 func WithAuth(auth interceptor.Auth) Opt {
 	return func(v *options) {
 		v.auth = auth
@@ -13,10 +13,15 @@ func New(opts ...Opt) *grpc.Server {
 		opts[i](conf)
 	}
 
+	loggingInterceptor := interceptor.NewLoggingServerInterceptor(conf.logLevel)
+	if conf.logger != nil {
+		loggingInterceptor = interceptor.NewLoggerServerInterceptor(conf.logger)
+	}
+
 	serverOpts := []grpc.ServerOption{
 		grpc.ChainUnaryInterceptor(
 			grpcmiddleware.ChainUnaryServer(conf.interceptors...),
-			interceptor.NewLoggingServerInterceptor(conf.logLevel),
+			loggingInterceptor,
 		),
 	}
 
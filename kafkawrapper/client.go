@@ -23,6 +23,7 @@ type Client interface {
 	NewConsumer(config ConsumerConfig) (Consumer, error)
 	NewSyncProducer(ctx context.Context, config ProducerConfig) (Producer, error)
 	NewConsumerGroup(config ConsumerGroupConfig) (ConsumerGroup, error)
+	Close() error
 }
 
 type CustomLogger interface {
@@ -15,12 +15,12 @@ var (
 )
 
 type Config struct {
-	KafkaPassword          string `env:"PASSWORD,required"`
-	KafkaUser              string `env:"USER,required"`
-	KafkaPort              string `env:"PORT,required"`
-	KafkaControllersCount  int    `env:"CONTROLLERS_COUNT,required"`
-	KafkaBrokerURLTemplate string `env:"BROKER_URL_TEMPLATE,required"`
-	KafkaReplicationFactor int    `env:"REPLICATION_FACTOR" envDefault:"3"`
-	KafkaEnableTLS         bool   `env:"ENABLE_TLS" envDefault:"true"`
-	KafkaPrefix            string `env:"PREFIX,required"`
+	KafkaPassword          string `env:"PASSWORD,required" desc:"SASL password"`
+	KafkaUser              string `env:"USER,required" desc:"SASL user"`
+	KafkaPort              string `env:"PORT,required" validate:"port" desc:"port of the brokers"`
+	KafkaControllersCount  int    `env:"CONTROLLERS_COUNT,required" validate:"min=1" desc:"count of the brokers"`
+	KafkaBrokerURLTemplate string `env:"BROKER_URL_TEMPLATE,required" desc:"broker host template filled with the broker number"`
+	KafkaReplicationFactor int    `env:"REPLICATION_FACTOR" envDefault:"3" validate:"min=1" desc:"replication factor of the created topics"`
+	KafkaEnableTLS         bool   `env:"ENABLE_TLS" envDefault:"true" desc:"connect with TLS"`
+	KafkaPrefix            string `env:"PREFIX,required" desc:"prefix of the topics and groups"`
 }
@@ -37,10 +37,10 @@ type consumer struct {
 }
 
 type ConsumerConfig struct {
-	Topic         string `env:"TOPIC,required"`
-	Key           string `env:"KEY" envDefault:""`
-	NumPartitions int    `env:"NUM_PARTITIONS" envDefault:"9"`
-	CreateTopic   bool   `env:"CREATE_TOPIC" envDefault:"true"`
+	Topic         string `env:"TOPIC,required" desc:"topic name without prefix"`
+	Key           string `env:"KEY" envDefault:"" desc:"consume only messages with the key"`
+	NumPartitions int    `env:"NUM_PARTITIONS" envDefault:"9" validate:"min=1" desc:"partitions of the created topic"`
+	CreateTopic   bool   `env:"CREATE_TOPIC" envDefault:"true" desc:"create the topic if it does not exist"`
 }
 
 func (c *client) NewConsumer(config ConsumerConfig) (Consumer, error) {
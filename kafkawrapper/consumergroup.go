@@ -29,10 +29,10 @@ type consumerGroup struct {
 }
 
 type ConsumerGroupConfig struct {
-	Topic         string `env:"TOPIC,required"`
-	Group         string `env:"GROUP,required"`
-	NumPartitions int    `env:"NUM_PARTITIONS" envDefault:"9"`
-	CreateTopic   bool   `env:"CREATE_TOPIC" envDefault:"true"`
+	Topic         string `env:"TOPIC,required" desc:"topic name without prefix"`
+	Group         string `env:"GROUP,required" desc:"consumer group name without prefix"`
+	NumPartitions int    `env:"NUM_PARTITIONS" envDefault:"9" validate:"min=1" desc:"partitions of the created topic"`
+	CreateTopic   bool   `env:"CREATE_TOPIC" envDefault:"true" desc:"create the topic if it does not exist"`
 }
 
 func (c *client) NewConsumerGroup(config ConsumerGroupConfig) (ConsumerGroup, error) {
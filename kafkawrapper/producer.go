@@ -10,9 +10,9 @@ import (
 )
 
 type ProducerConfig struct {
-	Topic         string `env:"TOPIC,required"`
-	NumPartitions int    `env:"NUM_PARTITIONS" envDefault:"9"`
-	CreateTopic   bool   `env:"CREATE_TOPIC" envDefault:"true"`
+	Topic         string `env:"TOPIC,required" desc:"topic name without prefix"`
+	NumPartitions int    `env:"NUM_PARTITIONS" envDefault:"9" validate:"min=1" desc:"partitions of the created topic"`
+	CreateTopic   bool   `env:"CREATE_TOPIC" envDefault:"true" desc:"create the topic if it does not exist"`
 }
 
 type Producer interface {
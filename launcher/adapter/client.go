@@ -0,0 +1,66 @@
+package adapter
+
+import (
+	"context"
+
+	kafka "github.com/tarmalonchik/golibs/kafkawrapper"
+	"github.com/tarmalonchik/golibs/launcher"
+	"github.com/tarmalonchik/golibs/logger"
+	"github.com/tarmalonchik/golibs/postgres"
+	"github.com/tarmalonchik/golibs/redis"
+	"github.com/tarmalonchik/golibs/trace"
+)
+
+// KafkaConsumerGroup processes messages of the consumer group until the runner context is cancelled.
+func KafkaConsumerGroup(
+	cg kafka.ConsumerGroup,
+	processor kafka.ProcessorFunc,
+	postProcessor kafka.PostProcessorFuncCG,
+) launcher.RunnerFunc {
+	return func(ctx context.Context) error {
+		if err := cg.Process(ctx, processor, postProcessor); err != nil {
+			return trace.FuncNameWithErrorMsg(err, "process consumer group")
+		}
+		return nil
+	}
+}
+
+// Closer makes a finisher from any close function, e.g. io.Closer.Close.
+func Closer(closeFunc func() error) launcher.RunnerFunc {
+	return func(_ context.Context) error {
+		return closeFunc()
+	}
+}
+
+// KafkaClient closes the kafka client, should be added with launcher.IsFinisher.
+func KafkaClient(client kafka.Client) launcher.RunnerFunc {
+	return Closer(client.Close)
+}
+
+// Postgres closes the database, should be added with launcher.IsFinisher.
+func Postgres(pg *postgres.Postgres) launcher.RunnerFunc {
+	return func(_ context.Context) error {
+		if pg == nil {
+			return nil
+		}
+		if err := pg.GetDB().Close(); err != nil {
+			return trace.FuncNameWithErrorMsg(err, "close postgres")
+		}
+		return nil
+	}
+}
+
+// Redis closes the redis client, should be added with launcher.IsFinisher.
+func Redis(client redis.Client) launcher.RunnerFunc {
+	return func(_ context.Context) error {
+		if err := client.Close(); err != nil {
+			return trace.FuncNameWithErrorMsg(err, "close redis")
+		}
+		return nil
+	}
+}
+
+// Logger flushes the logger, should be added with launcher.IsFinisher.
+func Logger(l *logger.Logger) launcher.RunnerFunc {
+	return l.Close
+}
@@ -0,0 +1,21 @@
+package adapter_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarmalonchik/golibs/launcher/adapter"
+)
+
+func TestCloser(t *testing.T) {
+	closeErr := errors.New("already closed")
+	require.ErrorIs(t, adapter.Closer(func() error { return closeErr })(context.Background()), closeErr)
+	require.NoError(t, adapter.Closer(func() error { return nil })(context.Background()))
+}
+
+func TestPostgresNil(t *testing.T) {
+	require.NoError(t, adapter.Postgres(nil)(context.Background()))
+}
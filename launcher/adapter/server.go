@@ -0,0 +1,96 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/tarmalonchik/golibs/launcher"
+	"github.com/tarmalonchik/golibs/trace"
+)
+
+// GRPCServer listens on addr and serves srv until the runner context is cancelled, then it calls GracefulStop.
+// The runner is marked ready once it listens, so it could be added with launcher.WaitReady.
+// The returned options make the launcher call Stop when the server did not stop within stopTimeout:
+//
+//	run, opts := adapter.GRPCServer(srv, ":8080", 20*time.Second)
+//	l.AddRunner("grpc", run, append(opts, launcher.WithPhase(2))...)
+func GRPCServer(srv *grpc.Server, addr string, stopTimeout time.Duration) (launcher.RunnerFunc, []launcher.RunnerOpt) {
+	run := func(ctx context.Context) error {
+		lis, err := (&net.ListenConfig{}).Listen(ctx, "tcp", addr)
+		if err != nil {
+			return trace.FuncNameWithErrorMsg(err, "listen")
+		}
+		launcher.MarkReady(ctx)
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- srv.Serve(lis)
+		}()
+
+		select {
+		case err = <-errCh:
+			if err != nil {
+				return trace.FuncNameWithErrorMsg(err, "serve")
+			}
+			return nil
+		case <-ctx.Done():
+		}
+
+		// returns when the calls are done or Stop is called by the launcher
+		srv.GracefulStop()
+
+		if err = <-errCh; err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			return trace.FuncNameWithErrorMsg(err, "serve")
+		}
+		return nil
+	}
+
+	return run, []launcher.RunnerOpt{
+		launcher.WithStopTimeout(stopTimeout),
+		launcher.WithHardStopFunc(srv.Stop),
+	}
+}
+
+// HTTPServer serves srv until the runner context is cancelled, then it calls Shutdown.
+// The returned options make the launcher call Close when the server did not stop within stopTimeout.
+// The server can not be served again once it is shut down or closed, so the runner should not be
+// repeated with launcher.RepeatOnFinish, launcher.RepeatOnError retries only the failed listen.
+func HTTPServer(srv *http.Server, stopTimeout time.Duration) (launcher.RunnerFunc, []launcher.RunnerOpt) {
+	run := func(ctx context.Context) error {
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- srv.ListenAndServe()
+		}()
+
+		select {
+		case err := <-errCh:
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return trace.FuncNameWithErrorMsg(err, "listen and serve")
+			}
+			return nil
+		case <-ctx.Done():
+		}
+
+		// returns when the connections are idle or closed by the launcher
+		if err := srv.Shutdown(context.WithoutCancel(ctx)); err != nil {
+			_ = srv.Close()
+		}
+
+		if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return trace.FuncNameWithErrorMsg(err, "listen and serve")
+		}
+		return nil
+	}
+
+	return run, []launcher.RunnerOpt{
+		launcher.WithStopTimeout(stopTimeout),
+		launcher.WithHardStopFunc(func() {
+			_ = srv.Close()
+		}),
+	}
+}
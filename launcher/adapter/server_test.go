@@ -0,0 +1,122 @@
+package adapter_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/tarmalonchik/golibs/launcher"
+	"github.com/tarmalonchik/golibs/launcher/adapter"
+	"github.com/tarmalonchik/golibs/launcher/launchertest"
+)
+
+func freeAddr(t *testing.T) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	require.NoError(t, lis.Close())
+	return lis.Addr().String()
+}
+
+func TestGRPCServer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	addr := freeAddr(t)
+	l, _ := launchertest.New()
+	run, opts := adapter.GRPCServer(grpc.NewServer(), addr, time.Second)
+	l.AddRunner("grpc", run, append(opts, launcher.WaitReady())...)
+
+	reportCh := make(chan launcher.Report, 1)
+	go func() {
+		reportCh <- l.Launch(context.Background())
+	}()
+	require.NoError(t, l.WaitStatus(ctx, "grpc", func(st launcher.RunnerStatus) bool { return st.Ready }))
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	l.Signal(syscall.SIGTERM)
+	report := <-reportCh
+	require.True(t, report.Clean())
+	require.Equal(t, []string{"grpc"}, report.Finished)
+}
+
+func TestGRPCServerListenError(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+
+	run, _ := adapter.GRPCServer(grpc.NewServer(), lis.Addr().String(), time.Second)
+	require.ErrorContains(t, run(context.Background()), "listen")
+}
+
+func TestHTTPServer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var (
+		addr     = freeAddr(t)
+		handling = make(chan struct{})
+		release  = make(chan struct{})
+	)
+	defer close(release)
+
+	l, clock := launchertest.New(launcher.WithTimeout(time.Minute))
+	run, opts := adapter.HTTPServer(&http.Server{
+		Addr: addr,
+		Handler: http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			close(handling)
+			<-release
+		}),
+		ReadHeaderTimeout: time.Second,
+	}, 5*time.Second)
+	l.AddRunner("http", run, opts...)
+
+	reportCh := make(chan launcher.Report, 1)
+	go func() {
+		reportCh <- l.Launch(context.Background())
+	}()
+	require.NoError(t, l.WaitState(ctx, "http", launcher.RunnerStateRunning))
+
+	reqErr := make(chan error, 1)
+	go func() {
+		// retries until the server listens
+		for {
+			resp, err := http.Get("http://" + addr) //nolint:noctx
+			if err == nil {
+				reqErr <- resp.Body.Close()
+				return
+			}
+			select {
+			case <-handling:
+				reqErr <- err
+				return
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	}()
+	select {
+	case <-handling:
+	case <-ctx.Done():
+		t.Fatal("request is not handled")
+	}
+
+	// the request in progress keeps the server running until the stop timeout closes it
+	l.Signal(syscall.SIGTERM)
+	require.NoError(t, clock.BlockUntil(ctx, 2))
+	clock.Advance(5 * time.Second)
+
+	report := <-reportCh
+	require.True(t, report.Clean())
+	require.Equal(t, []string{"http"}, report.Finished)
+	require.Error(t, <-reqErr)
+}
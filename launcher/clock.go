@@ -0,0 +1,71 @@
+package launcher
+
+import (
+	"context"
+	"time"
+)
+
+// Clock is the source of time for the launcher, it is replaced in tests to avoid real sleeps.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func WithClock(clock Clock) Opt {
+	return func(v *launcher) {
+		v.clock = clock
+	}
+}
+
+func clockFromContext(ctx context.Context) Clock {
+	if r, ok := ctx.Value(runnerCtxKey{}).(*runner); ok {
+		return r.clock
+	}
+	return realClock{}
+}
+
+// WaitStatus blocks until the status of the runner with the name satisfies cond or ctx is done.
+func (c *launcher) WaitStatus(ctx context.Context, name string, cond func(RunnerStatus) bool) error {
+	for {
+		c.stateMu.Lock()
+		changed := c.stateChanged
+		c.stateMu.Unlock()
+
+		for _, st := range c.Status() {
+			if st.Name == name && cond(st) {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// WaitState blocks until the runner with the name reaches the state or ctx is done.
+func (c *launcher) WaitState(ctx context.Context, name string, state RunnerState) error {
+	return c.WaitStatus(ctx, name, func(st RunnerStatus) bool {
+		return st.State == state
+	})
+}
+
+func (c *launcher) notifyStateChanged() {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+
+	close(c.stateChanged)
+	c.stateChanged = make(chan struct{})
+}
@@ -0,0 +1,115 @@
+package launcher
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrShuttingDown  = errors.New("launcher is shutting down")
+	ErrDuplicateName = errors.New("runner with the name is already registered")
+)
+
+// Handle controls a single runner registered with Spawn.
+type Handle interface {
+	Name() string
+	// Stop cancels the context of the runner and prevents it from restarting.
+	// The runner is removed from Status once it is done.
+	Stop()
+	// Done is closed when the runner is done.
+	Done() <-chan struct{}
+	Status() RunnerStatus
+}
+
+type handle struct {
+	launcher *launcher
+	runner   *runner
+}
+
+func (h *handle) Name() string {
+	return h.runner.name
+}
+
+func (h *handle) Stop() {
+	h.launcher.mu.Lock()
+	h.runner.detached = true
+	select {
+	case <-h.runner.doneCh:
+		h.launcher.removeLocked(h.runner)
+	default:
+	}
+	h.launcher.mu.Unlock()
+
+	h.runner.stop()
+	go h.launcher.expireRunner(h.runner)
+}
+
+func (h *handle) Done() <-chan struct{} {
+	return h.runner.doneCh
+}
+
+func (h *handle) Status() RunnerStatus {
+	return h.runner.status()
+}
+
+// Spawn registers the runner. Before Launch the runner is started with its phase,
+// after Launch it is started right away. It is safe to call Spawn concurrently with Launch.
+// The name should be unique among the registered runners, the name of the stopped runner
+// could be reused once it is done.
+func (c *launcher) Spawn(name string, in RunnerFunc, opts ...RunnerOpt) (Handle, error) {
+	r := newRunner(name, in, opts...)
+	if r.optErr != nil {
+		return nil, r.optErr
+	}
+
+	if r.isFinisher && (r.repeatOnFinish || r.repeatOnPanic || r.repeatOnError) {
+		panic("finisher should have no repeater")
+	}
+
+	if r.isScheduled() && (r.isFinisher || r.repeatOnFinish || r.repeatOnPanic || r.repeatOnError) {
+		panic("scheduled runner should have no repeater and can not be a finisher")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed || (c.started && !r.isFinisher && c.runCtx.Err() != nil) {
+		return nil, ErrShuttingDown
+	}
+
+	if r.name != "" && c.registeredLocked(r.name) {
+		return nil, fmt.Errorf("%s: %w", r.name, ErrDuplicateName)
+	}
+
+	r.clock = c.clock
+	r.notify = c.notifyStateChanged
+	r.onError = func(err error) {
+		c.reportError(runnerError{runner: r, err: err})
+	}
+
+	for r.name == "" || c.registeredLocked(r.name) {
+		r.name = fmt.Sprintf("runner-%d", c.seq)
+		c.seq++
+	}
+	c.registry = append(c.registry, r)
+
+	if c.started && !r.isFinisher {
+		c.startLocked(r)
+	}
+
+	return &handle{launcher: c, runner: r}, nil
+}
+
+func (c *launcher) registeredLocked(name string) bool {
+	return slices.ContainsFunc(c.registry, func(r *runner) bool {
+		return r.name == name
+	})
+}
+
+// removeLocked removes the runner from the registry, c.mu should be held.
+func (c *launcher) removeLocked(item *runner) {
+	c.registry = slices.DeleteFunc(c.registry, func(r *runner) bool {
+		return r == item
+	})
+}
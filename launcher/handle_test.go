@@ -0,0 +1,58 @@
+package launcher_test
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarmalonchik/golibs/launcher"
+	"github.com/tarmalonchik/golibs/launcher/launchertest"
+)
+
+func TestSpawn(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	l, _ := launchertest.New()
+	waitCancel := func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}
+	l.AddRunner("server", waitCancel)
+
+	_, err := l.Spawn("server", waitCancel)
+	require.ErrorIs(t, err, launcher.ErrDuplicateName)
+
+	reportCh := make(chan launcher.Report, 1)
+	go func() {
+		reportCh <- l.Launch(context.Background())
+	}()
+	require.NoError(t, l.WaitState(ctx, "server", launcher.RunnerStateRunning))
+
+	h, err := l.Spawn("job", waitCancel)
+	require.NoError(t, err)
+	require.Equal(t, "job", h.Name())
+	require.NoError(t, l.WaitState(ctx, "job", launcher.RunnerStateRunning))
+
+	h.Stop()
+	<-h.Done()
+	require.Equal(t, launcher.RunnerStateFinished, h.Status().State)
+	require.Eventually(t, func() bool { return len(l.Status()) == 1 }, time.Second, time.Millisecond)
+
+	// the name of the stopped runner is free again
+	h, err = l.Spawn("job", waitCancel)
+	require.NoError(t, err)
+	require.NoError(t, l.WaitState(ctx, "job", launcher.RunnerStateRunning))
+
+	l.Signal(syscall.SIGTERM)
+	report := <-reportCh
+	require.True(t, report.Clean())
+	require.ElementsMatch(t, []string{"server", "job"}, report.Finished)
+
+	_, err = l.Spawn("late", waitCancel)
+	require.ErrorIs(t, err, launcher.ErrShuttingDown)
+	<-h.Done()
+}
@@ -0,0 +1,132 @@
+package launcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type healthResponse struct {
+	Status  string         `json:"status"`
+	Runners []healthRunner `json:"runners"`
+}
+
+type healthRunner struct {
+	Name     string      `json:"name"`
+	State    RunnerState `json:"state"`
+	Ready    bool        `json:"ready"`
+	Restarts int         `json:"restarts"`
+	Error    string      `json:"error,omitempty"`
+}
+
+func (c *launcher) HealthHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		writeHealth(w, c.isLive(), c.Status())
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, _ *http.Request) {
+		writeHealth(w, c.isReady(), c.Status())
+	})
+	return mux
+}
+
+// isLive reports false when any registered runner died and is not going to be restarted.
+// The runners stopped through Handle are skipped, they are removed from the registry once done.
+func (c *launcher) isLive() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, item := range c.registry {
+		if !item.detached && item.status().State == RunnerStateFailed {
+			return false
+		}
+	}
+	return true
+}
+
+// isReady reports true when launch has begun, shutdown has not and every runner
+// which is still expected to work is running and ready.
+func (c *launcher) isReady() bool {
+	if !c.launched.Load() || c.shuttingDown.Load() {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, item := range c.registry {
+		if item.isFinisher {
+			continue
+		}
+
+		st := item.status()
+		switch st.State {
+		case RunnerStateFinished, RunnerStateFailed:
+			continue
+		case RunnerStateRunning, RunnerStateScheduled:
+			if !st.Ready {
+				return false
+			}
+		case RunnerStatePending, RunnerStateRestarting:
+			return false
+		}
+	}
+	return true
+}
+
+func writeHealth(w http.ResponseWriter, ok bool, statuses []RunnerStatus) {
+	resp := healthResponse{
+		Status:  "ok",
+		Runners: make([]healthRunner, 0, len(statuses)),
+	}
+	for _, st := range statuses {
+		item := healthRunner{
+			Name:     st.Name,
+			State:    st.State,
+			Ready:    st.Ready,
+			Restarts: st.Restarts,
+		}
+		if st.LastError != nil {
+			item.Error = st.LastError.Error()
+		}
+		resp.Runners = append(resp.Runners, item)
+	}
+
+	code := http.StatusOK
+	if !ok {
+		resp.Status = "fail"
+		code = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (c *launcher) startHealthServer() (stop func()) {
+	if c.healthAddr == "" {
+		return func() {}
+	}
+
+	srv := &http.Server{
+		Addr:              c.healthAddr,
+		Handler:           c.HealthHandler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			c.logger.Error("health server stopped", zap.Error(err))
+		}
+	}()
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = srv.Shutdown(ctx)
+	}
+}
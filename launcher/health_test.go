@@ -0,0 +1,107 @@
+package launcher_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarmalonchik/golibs/launcher"
+	"github.com/tarmalonchik/golibs/launcher/launchertest"
+)
+
+type healthBody struct {
+	Status  string `json:"status"`
+	Runners []struct {
+		Name  string `json:"name"`
+		State string `json:"state"`
+		Ready bool   `json:"ready"`
+		Error string `json:"error"`
+	} `json:"runners"`
+}
+
+func getHealth(t *testing.T, h http.Handler, path string) (int, healthBody) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var body healthBody
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	return rec.Code, body
+}
+
+func TestHealthHandler(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	l, _ := launchertest.New()
+	h := l.HealthHandler()
+
+	ready := make(chan struct{})
+	l.AddRunner("server", func(ctx context.Context) error {
+		<-ready
+		launcher.MarkReady(ctx)
+		<-ctx.Done()
+		return nil
+	}, launcher.WaitReady())
+
+	code, body := getHealth(t, h, "/healthz")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "ok", body.Status)
+
+	code, body = getHealth(t, h, "/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "fail", body.Status)
+
+	reportCh := make(chan launcher.Report, 1)
+	go func() {
+		reportCh <- l.Launch(context.Background())
+	}()
+	require.NoError(t, l.WaitState(ctx, "server", launcher.RunnerStateRunning))
+
+	code, _ = getHealth(t, h, "/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+
+	close(ready)
+	require.NoError(t, l.WaitStatus(ctx, "server", func(st launcher.RunnerStatus) bool { return st.Ready }))
+
+	code, body = getHealth(t, h, "/readyz")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, body.Runners, 1)
+	require.Equal(t, "server", body.Runners[0].Name)
+	require.True(t, body.Runners[0].Ready)
+
+	// the failed runner makes the process not live until it is stopped and removed
+	job, err := l.Spawn("job", func(context.Context) error {
+		return errors.New("job failed")
+	})
+	require.NoError(t, err)
+	<-job.Done()
+
+	code, body = getHealth(t, h, "/healthz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Len(t, body.Runners, 2)
+	require.Equal(t, "job failed", body.Runners[1].Error)
+
+	code, _ = getHealth(t, h, "/readyz")
+	require.Equal(t, http.StatusOK, code)
+
+	job.Stop()
+	code, body = getHealth(t, h, "/healthz")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, body.Runners, 1)
+
+	l.Signal(syscall.SIGTERM)
+	require.True(t, (<-reportCh).Clean())
+
+	code, _ = getHealth(t, h, "/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+}
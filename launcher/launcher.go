@@ -4,11 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
-	"os/signal"
-	"strings"
+	"sync"
 	"sync/atomic"
-	"syscall"
 	"time"
 
 	"go.uber.org/zap"
@@ -19,30 +18,72 @@ type Logger interface {
 }
 
 type Launcher interface {
-	Launch(ctx context.Context)
+	Launch(ctx context.Context) Report
 	AddRunners(in []RunnerFunc, opts ...RunnerOpt)
+	AddRunner(name string, in RunnerFunc, opts ...RunnerOpt)
+	Spawn(name string, in RunnerFunc, opts ...RunnerOpt) (Handle, error)
+	Status() []RunnerStatus
+	HealthHandler() http.Handler
+	OnReload(hook ReloadFunc)
+	Signal(sig os.Signal)
+	WaitState(ctx context.Context, name string, state RunnerState) error
+	WaitStatus(ctx context.Context, name string, cond func(RunnerStatus) bool) error
 }
 
-type launcher struct {
-	runnableCount  int64
-	finishersCount int64
-	parallelCount  int64
-
-	runnableStack  chan *runner // stack with jobs need to run
-	finishersStack chan *runner // stack with jobs run when finish
+type runnerError struct {
+	runner *runner
+	err    error
+}
 
-	errorChan chan error         // stack with errors
+type launcher struct {
+	parallelCount int64
+
+	mu          sync.Mutex
+	registry    []*runner       // all registered runners in order of registration
+	runCtx      context.Context // context of the runnable runners, set when runners are started
+	started     bool            // runners snapshot is taken, new runners are started right away
+	startupDone bool            // all phases are started
+	closed      bool            // all runnable runners are done, no more runners can be started
+	active      int             // count of the runnable runners which are not done yet
+	allDone     chan struct{}   // closed when all runnable runners are done
+	seq         int             // sequence for unnamed runners
+
+	errorChan chan runnerError   // stack with errors
+	errMu     sync.RWMutex       // guards errorChan from sends after it is closed
+	errClosed bool               // errorChan is closed, late errors are logged directly
 	logger    Logger             // you can use this logger for custom logging
 	cancel    context.CancelFunc // context.Cancel func
 	timeout   time.Duration      // time when forced termination will happen after crushing
 	jobsDone  chan interface{}   // the channel to signal when all work done
 
 	repeaterPeriod time.Duration
+
+	launched     atomic.Bool
+	shuttingDown atomic.Bool
+	healthAddr   string
+
+	shutdownSignals []os.Signal
+	reloadSignals   []os.Signal
+	reloadHooks     []ReloadFunc
+	forceExit       bool
+	forceExitCode   int
+	exit            func(code int)
+	signals         chan os.Signal
+	osSignals       bool
+	notifyReload    func()        // subscribes to the reload signals when the first hook is added after Launch
+	launchDone      chan struct{} // closed when Launch returns
+
+	clock        Clock
+	stateMu      sync.Mutex
+	stateChanged chan struct{} // closed and replaced on every runner state change
 }
 
 func NewLauncher(opts ...Opt) Launcher {
 	l := &launcher{
 		repeaterPeriod: 1 * time.Second,
+		exit:           os.Exit,
+		osSignals:      true,
+		clock:          realClock{},
 	}
 
 	for i := range opts {
@@ -61,174 +102,332 @@ func NewLauncher(opts ...Opt) Launcher {
 		l.parallelCount = 100
 	}
 
-	l.runnableStack = make(chan *runner, l.parallelCount)
-	l.finishersStack = make(chan *runner, l.parallelCount)
-	l.errorChan = make(chan error, l.parallelCount)
+	l.errorChan = make(chan runnerError, l.parallelCount)
 	l.jobsDone = make(chan interface{})
+	l.allDone = make(chan struct{})
+	l.signals = make(chan os.Signal, 8)
+	l.launchDone = make(chan struct{})
+	l.stateChanged = make(chan struct{})
 
 	return l
 }
 
 func (c *launcher) AddRunners(in []RunnerFunc, opts ...RunnerOpt) {
-	r := &runner{}
-	for i := range opts {
-		opts[i](r)
+	for i := range in {
+		c.AddRunner("", in[i], opts...)
 	}
+}
 
-	if r.isFinisher && (r.repeatOnFinish || r.repeatOnPanic || r.repeatOnError) {
-		panic("finisher should have no repeater")
+func (c *launcher) AddRunner(name string, in RunnerFunc, opts ...RunnerOpt) {
+	if _, err := c.Spawn(name, in, opts...); err != nil {
+		c.logger.Error(fmt.Sprintf("adding runner %s", name), zap.Error(err))
 	}
+}
 
-	if r.isFinisher {
-		for i := range in {
-			c.finishersStack <- newRunner(in[i], opts...)
-			c.incFinishers()
-		}
-	} else {
-		for i := range in {
-			c.runnableStack <- newRunner(in[i], opts...)
-			c.inc()
-		}
+func (c *launcher) Status() []RunnerStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := make([]RunnerStatus, 0, len(c.registry))
+	for i := range c.registry {
+		out = append(out, c.registry[i].status())
 	}
+	return out
 }
 
-func (c *launcher) Launch(ctx context.Context) {
+func (c *launcher) Launch(ctx context.Context) Report {
 	originalContext := ctx
 	ctx, cancel := context.WithCancel(ctx)
 	c.cancel = cancel
 
-	go c.waitForInterruption()
+	stopHealth := c.startHealthServer()
+	defer stopHealth()
+
+	c.launched.Store(true)
+
+	defer close(c.launchDone)
+
+	go c.waitForInterruption(ctx)
 	go c.logErrors(ctx)
 	go c.runRunners(originalContext, ctx)
 
 	<-ctx.Done()
+	c.shuttingDown.Store(true)
+	shutdownStarted := c.clock.Now()
+
+	go c.stopRunners()
 
-	c.waitGraceful()
+	timedOut := c.waitGraceful()
+
+	return c.buildReport(timedOut, c.clock.Now().Sub(shutdownStarted))
 }
 
 func (c *launcher) runRunners(originalContext, ctx context.Context) {
-	for item := range c.runnableStack {
+	c.mu.Lock()
+	c.runCtx = ctx
+	c.started = true
+	runnables, _ := c.snapshotLocked()
+	c.mu.Unlock()
+
+	phases := groupByPhase(runnables)
+	for i, phase := range phases {
 		if ctx.Err() != nil {
-			close(c.runnableStack)
-			continue
+			break
 		}
-		go func(*runner) {
-			wasPanic, err := item.run(ctx)
-			if err != nil {
-				c.errorChan <- err
-			}
 
-			if wasPanic && item.repeatOnPanic {
-				time.Sleep(c.repeaterPeriod)
-				c.runnableStack <- item
-				return
-			}
+		c.mu.Lock()
+		for _, item := range phase {
+			c.startLocked(item)
+		}
+		c.mu.Unlock()
 
-			if err != nil && !wasPanic && item.repeatOnError {
-				time.Sleep(c.repeaterPeriod)
-				c.runnableStack <- item
-				return
-			}
+		if !c.waitPhaseReady(ctx, phase, i == len(phases)-1) {
+			break
+		}
+	}
 
-			if item.repeatOnFinish {
-				time.Sleep(c.repeaterPeriod)
-				c.runnableStack <- item
-				return
-			}
+	c.mu.Lock()
+	c.startupDone = true
+	c.closeIfIdleLocked()
+	c.mu.Unlock()
 
-			if c.dec() <= 0 {
-				close(c.runnableStack)
-			}
-		}(item)
-	}
+	<-c.allDone
 
-	if c.finishersCount <= 0 {
-		close(c.errorChan)
-		c.cancel()
-		return
-	}
+	_, finishers := c.snapshot()
+
+	var wg sync.WaitGroup
 
-	for item := range c.finishersStack {
+	phases = groupByPhase(finishers)
+	for i := len(phases) - 1; i >= 0; i-- {
 		if originalContext.Err() != nil {
-			close(c.finishersStack)
-			continue
+			break
 		}
 
-		go func(*runner) {
-			_, err := item.run(originalContext)
-			if err != nil {
-				c.errorChan <- err
+		for _, item := range phases[i] {
+			if !item.setCancel(func() {}) {
+				continue
 			}
 
-			if c.decFinishers() <= 0 {
-				close(c.finishersStack)
-			}
-		}(item)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := item.run(originalContext)
+				if err != nil {
+					c.errorChan <- runnerError{runner: item, err: err}
+				}
+				item.setDone(err)
+			}()
+		}
+		wg.Wait()
 	}
+
+	c.errMu.Lock()
+	c.errClosed = true
 	close(c.errorChan)
+	c.errMu.Unlock()
 	c.cancel()
 }
 
-func (c *launcher) logErrors(ctx context.Context) {
-	for err := range c.errorChan {
-		c.logErr(ctx, err)
+// startLocked starts the runnable runner, c.mu should be held.
+// The runner is cancelled by stopRunners in its phase order, not with the launch context.
+func (c *launcher) startLocked(item *runner) {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(c.runCtx))
+	if !item.setCancel(cancel) {
+		cancel()
+		item.setDone(nil)
+		if item.detached {
+			c.removeLocked(item)
+		}
+		return
 	}
-	c.jobsDone <- nil
+
+	c.active++
+	go func() {
+		defer cancel()
+		c.supervise(ctx, item)
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		c.active--
+		if item.detached {
+			c.removeLocked(item)
+		}
+		c.closeIfIdleLocked()
+	}()
+}
+
+// closeIfIdleLocked signals that all runnable runners are done once every phase is started, c.mu should be held.
+func (c *launcher) closeIfIdleLocked() {
+	if c.active > 0 || c.closed || !c.startupDone {
+		return
+	}
+	c.closed = true
+	close(c.allDone)
 }
 
-func (c *launcher) logErr(ctx context.Context, err error) {
-	if errors.Is(err, context.Canceled) {
+func (c *launcher) snapshot() (runnables, finishers []*runner) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.snapshotLocked()
+}
+
+func (c *launcher) snapshotLocked() (runnables, finishers []*runner) {
+	for _, item := range c.registry {
+		if item.isFinisher {
+			finishers = append(finishers, item)
+			continue
+		}
+		runnables = append(runnables, item)
+	}
+	return runnables, finishers
+}
+
+// waitPhaseReady blocks until every runner of the phase is ready or done.
+// It returns false when the next phase should not be started: a WaitReady runner failed before it became ready,
+// so the runners of the next phases could not work. Errors of the other runners are only logged.
+func (c *launcher) waitPhaseReady(ctx context.Context, phase []*runner, last bool) bool {
+	for _, item := range phase {
 		select {
 		case <-ctx.Done():
-			return
-		default:
-			c.logErrAddPanicPrefix(err)
-			return
+			return false
+		case <-item.readyCh:
+		case <-item.doneCh:
 		}
 	}
-	c.logErrAddPanicPrefix(err)
+
+	if last {
+		return true
+	}
+
+	for _, item := range phase {
+		if item.waitReady && item.failedBeforeReady() {
+			c.logger.Error(
+				fmt.Sprintf("runner %s failed before it became ready, cancelling launch", item.name),
+				zap.String("runner", item.name),
+			)
+			c.cancel()
+			return false
+		}
+	}
+	return true
 }
 
-func (c *launcher) logErrAddPanicPrefix(err error) {
-	if strings.Contains(err.Error(), ErrPanic.Error()) {
-		c.logger.Error(fmt.Sprintf("panic happened: %s", err.Error()))
+func (c *launcher) supervise(ctx context.Context, item *runner) {
+	if item.isScheduled() {
+		c.runScheduled(ctx, item)
+		return
+	}
+
+	for {
+		wasPanic, err := item.run(ctx)
+		if err != nil {
+			c.errorChan <- runnerError{runner: item, err: err}
+		}
+
+		if ctx.Err() == nil && c.shouldRepeat(item, wasPanic, err) {
+			if delay, ok := item.nextDelay(c.repeaterPeriod, c.clock.Now()); ok {
+				item.setRestarting(err)
+				if c.sleep(ctx, delay) {
+					continue
+				}
+			} else {
+				c.budgetExhausted(item)
+			}
+		}
+
+		if ctx.Err() != nil && errors.Is(err, context.Canceled) {
+			err = nil
+		}
+		item.setDone(err)
 		return
 	}
-	c.logger.Error(fmt.Sprintf("error happened: %s", err.Error()))
 }
 
-func (c *launcher) waitGraceful() {
-	timeout, cancel := context.WithTimeout(context.Background(), c.timeout)
-	defer cancel()
+func (c *launcher) shouldRepeat(item *runner, wasPanic bool, err error) bool {
+	switch {
+	case wasPanic && item.repeatOnPanic:
+		return true
+	case err != nil && !wasPanic && item.repeatOnError:
+		return true
+	default:
+		return item.repeatOnFinish
+	}
+}
+
+func (c *launcher) budgetExhausted(item *runner) {
+	c.logger.Error(
+		fmt.Sprintf("restart budget exhausted for %s", item.name),
+		zap.String("runner", item.name),
+		zap.String("action", item.budget.action.String()),
+	)
+
+	if item.budget.action == BudgetActionCancelAll {
+		c.cancel()
+	}
+}
 
+func (c *launcher) sleep(ctx context.Context, d time.Duration) bool {
 	select {
-	case <-timeout.Done():
-		fmt.Println("graceful timeout done")
-		return
-	case <-c.jobsDone:
-		fmt.Println("jobs done")
-		return
+	case <-ctx.Done():
+		return false
+	case <-c.clock.After(d):
+		return true
 	}
 }
 
-func (c *launcher) waitForInterruption() {
-	ch := make(chan os.Signal, 1)
-	signal.Notify(ch, syscall.SIGINT, syscall.SIGTERM)
-	<-ch
-	c.cancel()
+func (c *launcher) logErrors(ctx context.Context) {
+	for item := range c.errorChan {
+		c.logErr(ctx, item)
+	}
+	close(c.jobsDone)
 }
 
-func (c *launcher) inc() {
-	atomic.AddInt64(&c.runnableCount, 1)
+// reportError passes the error reported by the runner itself, it could happen after the launcher is finished.
+func (c *launcher) reportError(item runnerError) {
+	c.errMu.RLock()
+	defer c.errMu.RUnlock()
+
+	if c.errClosed {
+		c.logErrAddPanicPrefix(item)
+		return
+	}
+	c.errorChan <- item
 }
 
-func (c *launcher) dec() int64 {
-	return atomic.AddInt64(&c.runnableCount, -1)
+func (c *launcher) logErr(ctx context.Context, item runnerError) {
+	if errors.Is(item.err, context.Canceled) {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			c.logErrAddPanicPrefix(item)
+			return
+		}
+	}
+	c.logErrAddPanicPrefix(item)
 }
 
-func (c *launcher) incFinishers() {
-	atomic.AddInt64(&c.finishersCount, 1)
+func (c *launcher) logErrAddPanicPrefix(item runnerError) {
+	fields := []zap.Field{zap.String("runner", item.runner.name)}
+	if len(item.runner.labels) > 0 {
+		fields = append(fields, zap.Any("labels", item.runner.labels))
+	}
+
+	if errors.Is(item.err, ErrPanic) {
+		c.logger.Error(fmt.Sprintf("panic happened in %s: %s", item.runner.name, item.err.Error()), fields...)
+		return
+	}
+	c.logger.Error(fmt.Sprintf("error happened in %s: %s", item.runner.name, item.err.Error()), fields...)
 }
-func (c *launcher) decFinishers() int64 {
-	return atomic.AddInt64(&c.finishersCount, -1)
+
+func (c *launcher) waitGraceful() (timedOut bool) {
+	select {
+	case <-c.clock.After(c.gracefulTimeout()):
+		c.logger.Error("graceful timeout expired before all jobs were done")
+		return true
+	case <-c.jobsDone:
+		return false
+	}
 }
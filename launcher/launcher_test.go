@@ -0,0 +1,207 @@
+package launcher_test
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarmalonchik/golibs/launcher"
+	"github.com/tarmalonchik/golibs/launcher/launchertest"
+)
+
+func TestRestartBudget(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	l, clock := launchertest.New()
+	l.AddRunner("failing", func(_ context.Context) error {
+		return errors.New("boom")
+	},
+		launcher.RepeatOnError(),
+		launcher.WithBackoff(launcher.Backoff{Initial: time.Second, Max: 10 * time.Second, Multiplier: 2}),
+		launcher.WithRestartBudget(3, time.Minute, launcher.BudgetActionGiveUp),
+	)
+
+	reportCh := make(chan launcher.Report, 1)
+	go func() {
+		reportCh <- l.Launch(context.Background())
+	}()
+
+	for _, delay := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second} {
+		require.NoError(t, clock.BlockUntil(ctx, 1))
+		require.NoError(t, l.WaitState(ctx, "failing", launcher.RunnerStateRestarting))
+		clock.Advance(delay - time.Millisecond)
+		require.Equal(t, 1, clock.Waiters())
+		clock.Advance(time.Millisecond)
+	}
+
+	require.NoError(t, l.WaitState(ctx, "failing", launcher.RunnerStateFailed))
+
+	report := <-reportCh
+	require.EqualError(t, report.Failed["failing"], "boom")
+	require.Equal(t, 1, report.ExitCode())
+
+	st := l.Status()
+	require.Len(t, st, 1)
+	require.Equal(t, 3, st[0].Restarts)
+}
+
+func TestBackoffDefaultInitial(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	l, clock := launchertest.New(launcher.WithRepeaterPeriod(time.Second))
+	l.AddRunner("failing", func(_ context.Context) error {
+		return errors.New("boom")
+	},
+		launcher.RepeatOnError(),
+		launcher.WithBackoff(launcher.Backoff{Max: 10 * time.Second}),
+		launcher.WithRestartBudget(2, time.Minute, launcher.BudgetActionGiveUp),
+	)
+
+	reportCh := make(chan launcher.Report, 1)
+	go func() {
+		reportCh <- l.Launch(context.Background())
+	}()
+
+	for _, delay := range []time.Duration{time.Second, 2 * time.Second} {
+		require.NoError(t, clock.BlockUntil(ctx, 1))
+		require.NoError(t, l.WaitState(ctx, "failing", launcher.RunnerStateRestarting))
+		clock.Advance(delay - time.Millisecond)
+		require.Equal(t, 1, clock.Waiters())
+		clock.Advance(time.Millisecond)
+	}
+
+	report := <-reportCh
+	require.EqualError(t, report.Failed["failing"], "boom")
+}
+
+func TestShutdownSignal(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	l, _ := launchertest.New()
+
+	var order []string
+	l.AddRunner("server", func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}, launcher.WithPhase(1))
+	l.AddRunner("close-db", func(_ context.Context) error {
+		order = append(order, "db")
+		return nil
+	}, launcher.IsFinisher(), launcher.WithPhase(0))
+	l.AddRunner("close-server", func(_ context.Context) error {
+		order = append(order, "server")
+		return nil
+	}, launcher.IsFinisher(), launcher.WithPhase(1))
+
+	reportCh := make(chan launcher.Report, 1)
+	go func() {
+		reportCh <- l.Launch(context.Background())
+	}()
+
+	require.NoError(t, l.WaitState(ctx, "server", launcher.RunnerStateRunning))
+	l.Signal(syscall.SIGTERM)
+
+	report := <-reportCh
+	require.True(t, report.Clean())
+	require.ElementsMatch(t, []string{"server", "close-db", "close-server"}, report.Finished)
+	require.Equal(t, []string{"server", "db"}, order)
+
+	// nobody reads the signals after Launch returned
+	for range 10 {
+		l.Signal(syscall.SIGTERM)
+	}
+}
+
+func TestShutdownPhaseOrder(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	l, _ := launchertest.New()
+
+	var order []string
+	stopped := func(name string) launcher.RunnerFunc {
+		return func(ctx context.Context) error {
+			<-ctx.Done()
+			order = append(order, name)
+			return nil
+		}
+	}
+	l.AddRunner("kafka-consumer", stopped("kafka"), launcher.WithPhase(1))
+	l.AddRunner("grpc-server", func(ctx context.Context) error {
+		<-ctx.Done()
+		// draining requests still needs the consumers of the previous phase
+		time.Sleep(50 * time.Millisecond)
+		order = append(order, "grpc")
+		return nil
+	}, launcher.WithPhase(2))
+	l.AddRunner("db", stopped("db"), launcher.WithPhase(0))
+
+	reportCh := make(chan launcher.Report, 1)
+	go func() {
+		reportCh <- l.Launch(context.Background())
+	}()
+
+	require.NoError(t, l.WaitState(ctx, "db", launcher.RunnerStateRunning))
+	require.NoError(t, l.WaitState(ctx, "grpc-server", launcher.RunnerStateRunning))
+	l.Signal(syscall.SIGTERM)
+
+	report := <-reportCh
+	require.True(t, report.Clean())
+	require.Equal(t, []string{"grpc", "kafka", "db"}, order)
+}
+
+func TestFailingRunnerDoesNotStopLaunch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	l, _ := launchertest.New()
+	l.AddRunner("job", func(_ context.Context) error {
+		return errors.New("boom")
+	})
+	l.AddRunner("server", func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+
+	reportCh := make(chan launcher.Report, 1)
+	go func() {
+		reportCh <- l.Launch(context.Background())
+	}()
+
+	require.NoError(t, l.WaitState(ctx, "job", launcher.RunnerStateFailed))
+	require.NoError(t, l.WaitState(ctx, "server", launcher.RunnerStateRunning))
+
+	select {
+	case <-reportCh:
+		t.Fatal("launch is finished because of the failed runner")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	l.Signal(syscall.SIGTERM)
+
+	report := <-reportCh
+	require.EqualError(t, report.Failed["job"], "boom")
+	require.Equal(t, []string{"server"}, report.Finished)
+}
+
+func TestFailedStartupPhase(t *testing.T) {
+	l, _ := launchertest.New()
+	l.AddRunner("migrations", func(_ context.Context) error {
+		return errors.New("dirty database")
+	}, launcher.WithPhase(0), launcher.WaitReady())
+	l.AddRunner("server", func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}, launcher.WithPhase(1))
+
+	report := l.Launch(context.Background())
+	require.EqualError(t, report.Failed["migrations"], "dirty database")
+	require.Equal(t, []string{"server"}, report.NotStarted)
+}
@@ -0,0 +1,20 @@
+package launchertest
+
+import (
+	"time"
+
+	"github.com/tarmalonchik/golibs/launcher"
+)
+
+// New creates a launcher driven by the fake clock which ignores os signals,
+// use Launcher.Signal to emulate them.
+func New(opts ...launcher.Opt) (launcher.Launcher, *Clock) {
+	clock := NewClock(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	opts = append([]launcher.Opt{
+		launcher.WithClock(clock),
+		launcher.WithoutOSSignals(),
+	}, opts...)
+
+	return launcher.NewLauncher(opts...), clock
+}
@@ -17,18 +17,30 @@ func WithLogger(logger Logger) Opt {
 
 func WithRepeaterPeriod(duration time.Duration) Opt {
 	return func(v *launcher) {
-		v.repeaterPeriod = 1 * time.Second
+		v.repeaterPeriod = duration
 	}
 }
 
+// WithTimeout sets the time every phase has to stop on shutdown, the runners without WithStopTimeout
+// are expired after it. The phases are stopped one by one, so the graceful timeout of the launcher
+// is the sum of the phase timeouts, each extended to the longest stop timeout of the phase.
 func WithTimeout(timeout time.Duration) Opt {
 	return func(v *launcher) {
 		v.timeout = timeout
 	}
 }
 
+// WithRunnersCount sets the size of the errors buffer. It does not limit how many runners execute,
+// use Pool for concurrency-limited work.
 func WithRunnersCount(count int64) Opt {
 	return func(v *launcher) {
 		v.parallelCount = count
 	}
 }
+
+// WithHealthServer starts http server with /healthz and /readyz endpoints on addr while Launch is running.
+func WithHealthServer(addr string) Opt {
+	return func(v *launcher) {
+		v.healthAddr = addr
+	}
+}
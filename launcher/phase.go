@@ -0,0 +1,58 @@
+package launcher
+
+import (
+	"context"
+	"slices"
+)
+
+type runnerCtxKey struct{}
+
+// WithPhase puts the runner into the startup phase. Phases are started in ascending order,
+// the next phase starts only when all runners of the previous phase are ready.
+// On shutdown phases are stopped in descending order, the next phase is cancelled when every runner
+// of the previous one is done or its stop timeout expired. Finishers are run in descending phase order too.
+func WithPhase(phase int) RunnerOpt {
+	return func(v *runner) {
+		v.phase = phase
+	}
+}
+
+// WaitReady makes the launcher wait for MarkReady call from the runner before starting the next phase.
+// Without this option the runner is treated as ready as soon as it is started.
+// A runner that finished without error is ready too, so one-shot jobs like migrations
+// can use WaitReady without calling MarkReady.
+func WaitReady() RunnerOpt {
+	return func(v *runner) {
+		v.waitReady = true
+	}
+}
+
+// MarkReady signals that the runner owning ctx is ready to serve.
+func MarkReady(ctx context.Context) {
+	if r, ok := ctx.Value(runnerCtxKey{}).(*runner); ok {
+		r.markReady()
+	}
+}
+
+// MarkNotReady signals that the runner owning ctx temporarily can not serve.
+func MarkNotReady(ctx context.Context) {
+	if r, ok := ctx.Value(runnerCtxKey{}).(*runner); ok {
+		r.setReady(false)
+	}
+}
+
+func groupByPhase(in []*runner) [][]*runner {
+	sorted := slices.Clone(in)
+	slices.SortStableFunc(sorted, func(a, b *runner) int {
+		return a.phase - b.phase
+	})
+
+	var out [][]*runner
+	for i, item := range sorted {
+		if i == 0 || sorted[i-1].phase != item.phase {
+			out = append(out, nil)
+		}
+		out[len(out)-1] = append(out[len(out)-1], item)
+	}
+	return out
+}
@@ -0,0 +1,149 @@
+package launcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+var (
+	ErrSourceDone = errors.New("job source is done")
+)
+
+type Job func(ctx context.Context) error
+
+// JobSource blocks until the next job is available. It returns ErrSourceDone when there are no more jobs.
+type JobSource func(ctx context.Context) (Job, error)
+
+type PoolOpt func(v *pool)
+
+type pool struct {
+	source     JobSource
+	workers    int
+	jobTimeout time.Duration
+	drain      bool
+	retryDelay time.Duration
+}
+
+// WithJobTimeout limits the duration of every job.
+func WithJobTimeout(timeout time.Duration) PoolOpt {
+	return func(v *pool) {
+		v.jobTimeout = timeout
+	}
+}
+
+// WithDrainOnShutdown lets in-flight jobs finish on shutdown instead of cancelling their context,
+// new jobs are not taken from the source. The context of the jobs is cancelled when the stop timeout
+// of the runner expires, or the timeout of the launcher when the runner has none.
+func WithDrainOnShutdown() PoolOpt {
+	return func(v *pool) {
+		v.drain = true
+	}
+}
+
+// ChanSource takes jobs from ch until it is closed.
+func ChanSource(ch <-chan Job) JobSource {
+	return func(ctx context.Context) (Job, error) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case job, ok := <-ch:
+			if !ok {
+				return nil, ErrSourceDone
+			}
+			return job, nil
+		}
+	}
+}
+
+// Pool runs workers which take jobs from the source until it is done or the runner context is cancelled.
+// Errors and panics of jobs are reported to the launcher and do not stop the pool.
+func Pool(source JobSource, workers int, opts ...PoolOpt) RunnerFunc {
+	if workers < 1 {
+		panic("pool should have at least one worker")
+	}
+
+	p := &pool{
+		source:     source,
+		workers:    workers,
+		retryDelay: time.Second,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p.run
+}
+
+func (p *pool) run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for range p.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.work(ctx)
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+func (p *pool) work(ctx context.Context) {
+	for ctx.Err() == nil {
+		job, err := p.next(ctx)
+		switch {
+		case errors.Is(err, ErrSourceDone):
+			return
+		case err != nil:
+			if ctx.Err() != nil {
+				return
+			}
+			ReportError(ctx, fmt.Errorf("taking job: %w", err))
+
+			select {
+			case <-ctx.Done():
+			case <-clockFromContext(ctx).After(p.retryDelay):
+			}
+			continue
+		}
+
+		if err = p.runJob(ctx, job); err != nil {
+			ReportError(ctx, err)
+		}
+	}
+}
+
+func (p *pool) next(ctx context.Context) (job Job, err error) {
+	defer func() {
+		if recoverErr := recover(); recoverErr != nil {
+			err = fmt.Errorf("%w: %v\n%s", ErrPanic, panicToString(recoverErr), string(debug.Stack()))
+		}
+	}()
+
+	return p.source(ctx)
+}
+
+func (p *pool) runJob(ctx context.Context, job Job) (err error) {
+	if p.drain {
+		var cancel context.CancelFunc
+		ctx, cancel = stopDeadlineContext(ctx)
+		defer cancel()
+	}
+
+	if p.jobTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.jobTimeout)
+		defer cancel()
+	}
+
+	defer func() {
+		if recoverErr := recover(); recoverErr != nil {
+			err = fmt.Errorf("%w: %v\n%s", ErrPanic, panicToString(recoverErr), string(debug.Stack()))
+		}
+	}()
+
+	return job(ctx)
+}
@@ -0,0 +1,136 @@
+package launcher_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/tarmalonchik/golibs/launcher"
+	"github.com/tarmalonchik/golibs/launcher/launchertest"
+)
+
+func TestPool(t *testing.T) {
+	core, logs := observer.New(zapcore.ErrorLevel)
+	l, _ := launchertest.New(launcher.WithLogger(zap.New(core)))
+
+	var (
+		jobs       = make(chan launcher.Job)
+		running    atomic.Int32
+		maxRunning atomic.Int32
+		done       atomic.Int32
+	)
+	l.AddRunner("pool", launcher.Pool(launcher.ChanSource(jobs), 2))
+
+	reportCh := make(chan launcher.Report, 1)
+	go func() {
+		reportCh <- l.Launch(context.Background())
+	}()
+
+	for i := range 6 {
+		jobs <- func(_ context.Context) error {
+			defer done.Add(1)
+
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				prev := maxRunning.Load()
+				if n <= prev || maxRunning.CompareAndSwap(prev, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+
+			switch i {
+			case 1:
+				return errors.New("job failed")
+			case 2:
+				panic("job panicked")
+			}
+			return nil
+		}
+	}
+	close(jobs)
+
+	report := <-reportCh
+	require.True(t, report.Clean())
+	require.Equal(t, []string{"pool"}, report.Finished)
+	require.EqualValues(t, 6, done.Load())
+	require.EqualValues(t, 2, maxRunning.Load())
+
+	require.Equal(t, 1, logs.FilterMessage("error happened in pool: job failed").Len())
+	require.Equal(t, 1, logs.FilterMessageSnippet("panic happened in pool").Len())
+}
+
+func TestPoolDrainOnShutdown(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	l, clock := launchertest.New(launcher.WithTimeout(time.Minute))
+
+	var (
+		jobs      = make(chan launcher.Job, 1)
+		started   = make(chan struct{})
+		cancelled = make(chan struct{})
+	)
+	l.AddRunner("pool", launcher.Pool(launcher.ChanSource(jobs), 1, launcher.WithDrainOnShutdown()),
+		launcher.WithStopTimeout(10*time.Second))
+
+	reportCh := make(chan launcher.Report, 1)
+	go func() {
+		reportCh <- l.Launch(context.Background())
+	}()
+
+	jobs <- func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		close(cancelled)
+		return ctx.Err()
+	}
+	<-started
+
+	l.Signal(syscall.SIGTERM)
+
+	// the graceful timeout and the stop timeout of the pool
+	require.NoError(t, clock.BlockUntil(ctx, 2))
+	clock.Advance(10*time.Second - time.Millisecond)
+
+	select {
+	case <-cancelled:
+		t.Fatal("drained job is cancelled before the stop timeout")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	clock.Advance(time.Millisecond)
+	<-cancelled
+
+	report := <-reportCh
+	require.True(t, report.Clean())
+	require.Equal(t, []string{"pool"}, report.Finished)
+}
+
+func TestReportErrorAfterLaunch(t *testing.T) {
+	core, logs := observer.New(zapcore.ErrorLevel)
+	l, _ := launchertest.New(launcher.WithLogger(zap.New(core)))
+
+	var runnerCtx context.Context
+	l.AddRunner("job", func(ctx context.Context) error {
+		runnerCtx = ctx
+		return nil
+	})
+
+	report := l.Launch(context.Background())
+	require.True(t, report.Clean())
+
+	require.NotPanics(t, func() {
+		launcher.ReportError(runnerCtx, errors.New("late failure"))
+	})
+	require.Equal(t, 1, logs.FilterMessage("error happened in job: late failure").Len())
+}
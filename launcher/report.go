@@ -0,0 +1,48 @@
+package launcher
+
+import "time"
+
+// Report describes how the launcher finished.
+type Report struct {
+	Finished         []string         // runners finished without error
+	Failed           map[string]error // runners finished with error or panic
+	Unfinished       []string         // runners still working when the graceful timeout expired
+	NotStarted       []string         // runners which never started, e.g. because of startup failure
+	TimedOut         bool             // graceful timeout expired before all jobs were done
+	ShutdownDuration time.Duration    // time from the shutdown start until Launch returned
+}
+
+// Clean reports whether all runners finished without errors before the graceful timeout.
+func (r Report) Clean() bool {
+	return !r.TimedOut && len(r.Failed) == 0 && len(r.Unfinished) == 0
+}
+
+// ExitCode maps the report to the process exit code: 0 for clean shutdown, 1 otherwise.
+func (r Report) ExitCode() int {
+	if r.Clean() {
+		return 0
+	}
+	return 1
+}
+
+func (c *launcher) buildReport(timedOut bool, shutdownDuration time.Duration) Report {
+	out := Report{
+		Failed:           make(map[string]error),
+		TimedOut:         timedOut,
+		ShutdownDuration: shutdownDuration,
+	}
+
+	for _, st := range c.Status() {
+		switch st.State {
+		case RunnerStateFinished:
+			out.Finished = append(out.Finished, st.Name)
+		case RunnerStateFailed:
+			out.Failed[st.Name] = st.LastError
+		case RunnerStatePending:
+			out.NotStarted = append(out.NotStarted, st.Name)
+		case RunnerStateRunning, RunnerStateRestarting, RunnerStateScheduled:
+			out.Unfinished = append(out.Unfinished, st.Name)
+		}
+	}
+	return out
+}
@@ -0,0 +1,105 @@
+package launcher
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff describes the delay between restarts of a repeating runner.
+// The delay starts from Initial, the repeater period of the launcher by default, and is multiplied
+// by Multiplier, 2 by default, after every restart until it reaches Max. Jitter is a fraction of the delay (0..1)
+// randomly added or subtracted to spread restarts of many runners.
+type Backoff struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+	Jitter     float64
+}
+
+func (b Backoff) delay(attempt int, defaultInitial time.Duration) time.Duration {
+	initial := b.Initial
+	if initial <= 0 {
+		initial = defaultInitial
+	}
+
+	multiplier := b.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	d := float64(initial) * math.Pow(multiplier, float64(attempt))
+	if b.Max > 0 && d > float64(b.Max) {
+		d = float64(b.Max)
+	}
+
+	if b.Jitter > 0 {
+		d += d * b.Jitter * (2*rand.Float64() - 1) //nolint:gosec
+	}
+
+	if d < 0 {
+		return 0
+	}
+	return time.Duration(d)
+}
+
+type restartBudget struct {
+	maxRestarts int
+	window      time.Duration
+	action      BudgetAction
+}
+
+func WithBackoff(backoff Backoff) RunnerOpt {
+	return func(v *runner) {
+		v.backoff = &backoff
+	}
+}
+
+// WithRestartBudget limits the runner to maxRestarts restarts within the sliding window.
+// When the budget is exhausted the runner is not restarted anymore and the action is applied.
+func WithRestartBudget(maxRestarts int, window time.Duration, action BudgetAction) RunnerOpt {
+	return func(v *runner) {
+		if !action.IsValid() {
+			panic("invalid budget action")
+		}
+		v.budget = &restartBudget{
+			maxRestarts: maxRestarts,
+			window:      window,
+			action:      action,
+		}
+	}
+}
+
+// nextDelay returns the delay before the next restart or false when the restart budget is exhausted.
+func (r *runner) nextDelay(defaultPeriod time.Duration, now time.Time) (time.Duration, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.budget != nil {
+		kept := r.restartTimes[:0]
+		for _, t := range r.restartTimes {
+			if now.Sub(t) < r.budget.window {
+				kept = append(kept, t)
+			}
+		}
+		r.restartTimes = kept
+
+		if len(r.restartTimes) >= r.budget.maxRestarts {
+			return 0, false
+		}
+		r.restartTimes = append(r.restartTimes, now)
+	}
+
+	if r.backoff == nil {
+		return defaultPeriod, true
+	}
+
+	// the runner was healthy long enough, so start the backoff from scratch
+	if r.backoff.Max > 0 && now.Sub(r.lastStart) > r.backoff.Max {
+		r.attempt = 0
+	}
+
+	d := r.backoff.delay(r.attempt, defaultPeriod)
+	r.attempt++
+	return d, true
+}
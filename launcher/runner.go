@@ -3,7 +3,10 @@ package launcher
 import (
 	"context"
 	"fmt"
+	"maps"
 	"runtime/debug"
+	"sync"
+	"time"
 )
 
 var (
@@ -13,9 +16,17 @@ var (
 type RunnerOpt func(v *runner)
 type RunnerFunc func(ctx context.Context) error
 
-func newRunner(runnerFunc RunnerFunc, options ...RunnerOpt) *runner {
+func newRunner(name string, runnerFunc RunnerFunc, options ...RunnerOpt) *runner {
 	r := runner{
+		name:       name,
 		runnerFunc: runnerFunc,
+		state:      RunnerStatePending,
+		clock:      realClock{},
+		notify:     func() {},
+		schedule:   schedule{overlap: OverlapPolicySkip},
+		readyCh:    make(chan struct{}),
+		doneCh:     make(chan struct{}),
+		expiredCh:  make(chan struct{}),
 	}
 	for _, opt := range options {
 		opt(&r)
@@ -47,16 +58,63 @@ func RepeatOnFinish() RunnerOpt {
 	}
 }
 
+func WithLabels(labels map[string]string) RunnerOpt {
+	return func(v *runner) {
+		v.labels = maps.Clone(labels)
+	}
+}
+
 type runner struct {
+	name           string
+	labels         map[string]string
 	runnerFunc     func(ctx context.Context) error
 	repeatOnError  bool
 	repeatOnPanic  bool
 	repeatOnFinish bool
 	isFinisher     bool
+	backoff        *Backoff
+	budget         *restartBudget
+	phase          int
+	waitReady      bool
+	schedule       schedule
+	stopTimeout    time.Duration
+	stopFunc       StopFunc
+	hardStopFunc   func()
+	detached       bool  // guarded by launcher mutex
+	optErr         error // the invalid option, Spawn returns it
+	onError        func(err error)
+	notify         func()
+	clock          Clock
+
+	readyOnce sync.Once
+	readyCh   chan struct{}
+	doneOnce  sync.Once
+	doneCh    chan struct{}
+
+	expireOnce sync.Once
+	expiredCh  chan struct{} // closed when the stop timeout expired and the runner is still running
+
+	mu           sync.Mutex
+	cancel       context.CancelFunc
+	stopped      bool
+	attempt      int
+	restartTimes []time.Time
+	state        RunnerState
+	ready        bool
+	restarts     int
+	lastErr      error
+	lastStart    time.Time
+}
+
+// ReportError logs err with the name of the runner owning ctx without stopping the runner.
+func ReportError(ctx context.Context, err error) {
+	if r, ok := ctx.Value(runnerCtxKey{}).(*runner); ok && r.onError != nil {
+		r.onError(err)
+	}
 }
 
 func (r *runner) run(ctx context.Context) (wasPanic bool, err error) {
-	ctx, cancel := context.WithCancel(ctx)
+	ctx, cancel := context.WithCancel(context.WithValue(ctx, runnerCtxKey{}, r))
 
 	defer func() {
 		cancel()
@@ -67,10 +125,129 @@ func (r *runner) run(ctx context.Context) (wasPanic bool, err error) {
 		<-ctx.Done()
 	}()
 
+	r.setStarted()
+	if !r.waitReady {
+		r.markReady()
+	}
+
 	err = r.runnerFunc(ctx)
 	return wasPanic, err
 }
 
+func (r *runner) setStarted() {
+	defer r.notify()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.state = RunnerStateRunning
+	r.ready = false
+	r.lastStart = r.clock.Now()
+}
+
+func (r *runner) setRestarting(err error) {
+	defer r.notify()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.state = RunnerStateRestarting
+	r.restarts++
+	if err != nil {
+		r.lastErr = err
+	}
+}
+
+func (r *runner) setScheduled(err error) {
+	defer r.notify()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.state = RunnerStateScheduled
+	r.ready = true
+	if err != nil {
+		r.lastErr = err
+	}
+}
+
+func (r *runner) markReady() {
+	r.setReady(true)
+	r.readyOnce.Do(func() {
+		close(r.readyCh)
+	})
+}
+
+func (r *runner) failedBeforeReady() bool {
+	select {
+	case <-r.readyCh:
+		return false
+	default:
+		return r.status().State == RunnerStateFailed
+	}
+}
+
+func (r *runner) setReady(ready bool) {
+	defer r.notify()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ready = ready
+}
+
+func (r *runner) setCancel(cancel context.CancelFunc) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stopped {
+		return false
+	}
+	r.cancel = cancel
+	return true
+}
+
+// stop cancels the runner and prevents it from starting again, it reports whether the runner was started.
+func (r *runner) stop() (started bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.stopped = true
+	if r.cancel != nil {
+		r.cancel()
+		return true
+	}
+	return false
+}
+
+func (r *runner) setDone(err error) {
+	defer r.notify()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	defer r.doneOnce.Do(func() {
+		close(r.doneCh)
+	})
+
+	r.ready = false
+	if err != nil {
+		r.state = RunnerStateFailed
+		r.lastErr = err
+		return
+	}
+	r.state = RunnerStateFinished
+}
+
+func (r *runner) status() RunnerStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return RunnerStatus{
+		Name:      r.name,
+		Labels:    maps.Clone(r.labels),
+		State:     r.state,
+		Ready:     r.ready,
+		Restarts:  r.restarts,
+		LastError: r.lastErr,
+		LastStart: r.lastStart,
+	}
+}
+
 func panicToString(panicErr interface{}) string {
 	if panicErr == nil {
 		return ""
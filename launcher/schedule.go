@@ -0,0 +1,151 @@
+package launcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Schedule returns the next activation time after the given one.
+type Schedule interface {
+	Next(time.Time) time.Time
+}
+
+type intervalSchedule time.Duration
+
+func (s intervalSchedule) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(s))
+}
+
+type schedule struct {
+	next      Schedule
+	jitter    time.Duration
+	overlap   OverlapPolicy
+	immediate bool
+}
+
+// WithInterval runs the runner every interval instead of once.
+func WithInterval(interval time.Duration) RunnerOpt {
+	if interval <= 0 {
+		panic("interval should be positive")
+	}
+	return WithSchedule(intervalSchedule(interval))
+}
+
+// WithCron runs the runner by the standard 5 fields cron expression, descriptors like @hourly are supported too.
+// The invalid expression is returned as error by Spawn.
+func WithCron(expr string) RunnerOpt {
+	s, err := cron.ParseStandard(expr)
+	if err != nil {
+		return func(v *runner) {
+			v.optErr = fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+	}
+	return WithSchedule(s)
+}
+
+func WithSchedule(s Schedule) RunnerOpt {
+	return func(v *runner) {
+		v.schedule.next = s
+	}
+}
+
+// WithScheduleJitter delays every scheduled run by a random duration in [0, jitter).
+func WithScheduleJitter(jitter time.Duration) RunnerOpt {
+	return func(v *runner) {
+		v.schedule.jitter = jitter
+	}
+}
+
+// WithOverlap defines what happens when the previous run is still in progress at activation time.
+// OverlapPolicySkip drops the activation, OverlapPolicyQueue starts the run right after the previous one,
+// several missed activations are merged into a single run.
+func WithOverlap(policy OverlapPolicy) RunnerOpt {
+	return func(v *runner) {
+		if !policy.IsValid() {
+			panic("invalid overlap policy")
+		}
+		v.schedule.overlap = policy
+	}
+}
+
+// WithImmediateRun makes the scheduled runner run once right after start without waiting for the first activation.
+func WithImmediateRun() RunnerOpt {
+	return func(v *runner) {
+		v.schedule.immediate = true
+	}
+}
+
+func (r *runner) isScheduled() bool {
+	return r.schedule.next != nil
+}
+
+func (c *launcher) runScheduled(ctx context.Context, item *runner) {
+	item.setScheduled(nil)
+	item.markReady()
+
+	var busy atomic.Bool
+	trigger := make(chan struct{}, 1)
+	workerDone := make(chan struct{})
+
+	go func() {
+		defer close(workerDone)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-trigger:
+				busy.Store(true)
+				c.runScheduledOnce(ctx, item)
+				busy.Store(false)
+			}
+		}
+	}()
+
+	fire := func() {
+		if item.schedule.overlap == OverlapPolicySkip && busy.Load() {
+			return
+		}
+		select {
+		case trigger <- struct{}{}:
+		default:
+		}
+	}
+
+	if item.schedule.immediate {
+		fire()
+	}
+
+	at := c.clock.Now()
+	for {
+		at = item.schedule.next.Next(at)
+		delay := at.Sub(c.clock.Now())
+		if item.schedule.jitter > 0 {
+			delay += time.Duration(rand.Int64N(int64(item.schedule.jitter))) //nolint:gosec
+		}
+
+		if !c.sleep(ctx, delay) {
+			break
+		}
+		fire()
+	}
+
+	<-workerDone
+	item.setDone(nil)
+}
+
+func (c *launcher) runScheduledOnce(ctx context.Context, item *runner) {
+	_, err := item.run(ctx)
+	if ctx.Err() != nil && errors.Is(err, context.Canceled) {
+		err = nil
+	}
+	if err != nil {
+		c.reportError(runnerError{runner: item, err: err})
+	}
+	item.setScheduled(err)
+}
@@ -0,0 +1,177 @@
+package launcher_test
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarmalonchik/golibs/launcher"
+	"github.com/tarmalonchik/golibs/launcher/launchertest"
+)
+
+func receive(t *testing.T, ch <-chan time.Time) time.Time {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(5 * time.Second):
+		t.Fatal("scheduled run did not happen")
+		return time.Time{}
+	}
+}
+
+func requireNoRun(t *testing.T, ch <-chan time.Time) {
+	t.Helper()
+	select {
+	case v := <-ch:
+		t.Fatalf("unexpected run at %s", v)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// launchScheduled starts the launcher with the scheduled runner, the runner sends the time of every run.
+func launchScheduled(
+	t *testing.T, job func(clock *launchertest.Clock) launcher.RunnerFunc, opts ...launcher.RunnerOpt,
+) (*launchertest.Clock, func()) {
+	t.Helper()
+
+	l, clock := launchertest.New()
+	l.AddRunner("scheduled", job(clock), opts...)
+
+	reportCh := make(chan launcher.Report, 1)
+	go func() {
+		reportCh <- l.Launch(context.Background())
+	}()
+
+	return clock, func() {
+		l.Signal(syscall.SIGTERM)
+		require.True(t, (<-reportCh).Clean())
+	}
+}
+
+func TestInterval(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	runs := make(chan time.Time, 10)
+	clock, stop := launchScheduled(t, func(clock *launchertest.Clock) launcher.RunnerFunc {
+		return func(_ context.Context) error {
+			runs <- clock.Now()
+			return nil
+		}
+	}, launcher.WithInterval(time.Minute), launcher.WithImmediateRun())
+	defer stop()
+
+	start := receive(t, runs)
+	for i := 1; i <= 2; i++ {
+		require.NoError(t, clock.BlockUntil(ctx, 1))
+		clock.Advance(time.Minute - time.Millisecond)
+		requireNoRun(t, runs)
+		clock.Advance(time.Millisecond)
+		require.Equal(t, start.Add(time.Duration(i)*time.Minute), receive(t, runs))
+	}
+}
+
+func TestCron(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	runs := make(chan time.Time, 10)
+	clock, stop := launchScheduled(t, func(clock *launchertest.Clock) launcher.RunnerFunc {
+		return func(_ context.Context) error {
+			runs <- clock.Now()
+			return nil
+		}
+	}, launcher.WithCron("*/5 * * * *"))
+	defer stop()
+
+	require.NoError(t, clock.BlockUntil(ctx, 1))
+	clock.Advance(4 * time.Minute)
+	requireNoRun(t, runs)
+	clock.Advance(time.Minute)
+	require.Equal(t, time.Date(2000, 1, 1, 0, 5, 0, 0, time.UTC), receive(t, runs))
+}
+
+func TestInvalidCron(t *testing.T) {
+	l, _ := launchertest.New()
+	_, err := l.Spawn("scheduled", func(context.Context) error { return nil }, launcher.WithCron("every minute"))
+	require.ErrorContains(t, err, `invalid cron expression "every minute"`)
+	require.Empty(t, l.Status())
+}
+
+func TestOverlap(t *testing.T) {
+	for _, tc := range []struct {
+		policy launcher.OverlapPolicy
+		queued bool
+	}{
+		{policy: launcher.OverlapPolicySkip},
+		{policy: launcher.OverlapPolicyQueue, queued: true},
+	} {
+		t.Run(tc.policy.String(), func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			runs := make(chan time.Time, 10)
+			release := make(chan struct{})
+			clock, stop := launchScheduled(t, func(clock *launchertest.Clock) launcher.RunnerFunc {
+				return func(ctx context.Context) error {
+					runs <- clock.Now()
+					select {
+					case <-release:
+					case <-ctx.Done():
+					}
+					return nil
+				}
+			}, launcher.WithInterval(time.Minute), launcher.WithOverlap(tc.policy))
+			defer stop()
+
+			start := clock.Now()
+			require.NoError(t, clock.BlockUntil(ctx, 1))
+			clock.Advance(time.Minute)
+			require.Equal(t, start.Add(time.Minute), receive(t, runs))
+
+			// the activation happens while the first run is in progress
+			require.NoError(t, clock.BlockUntil(ctx, 1))
+			clock.Advance(time.Minute)
+			require.NoError(t, clock.BlockUntil(ctx, 1))
+
+			release <- struct{}{}
+			if tc.queued {
+				require.Equal(t, start.Add(2*time.Minute), receive(t, runs))
+				release <- struct{}{}
+			} else {
+				requireNoRun(t, runs)
+			}
+
+			clock.Advance(time.Minute)
+			require.Equal(t, start.Add(3*time.Minute), receive(t, runs))
+		})
+	}
+}
+
+func TestScheduleJitter(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	runs := make(chan time.Time, 10)
+	clock, stop := launchScheduled(t, func(clock *launchertest.Clock) launcher.RunnerFunc {
+		return func(_ context.Context) error {
+			runs <- clock.Now()
+			return nil
+		}
+	}, launcher.WithInterval(time.Minute), launcher.WithScheduleJitter(30*time.Second))
+	defer stop()
+
+	start := clock.Now()
+	require.NoError(t, clock.BlockUntil(ctx, 1))
+	clock.Advance(time.Minute - time.Millisecond)
+	requireNoRun(t, runs)
+
+	clock.Advance(30 * time.Second)
+	at := receive(t, runs)
+	require.False(t, at.Before(start.Add(time.Minute)))
+	require.True(t, at.Before(start.Add(time.Minute+30*time.Second)))
+}
@@ -0,0 +1,165 @@
+package launcher
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+
+	"go.uber.org/zap"
+)
+
+// ReloadFunc is called on reload signal, e.g. to re-read config or change log level.
+type ReloadFunc func(ctx context.Context) error
+
+// WithShutdownSignals replaces the signals which start graceful shutdown, SIGINT and SIGTERM by default.
+func WithShutdownSignals(signals ...os.Signal) Opt {
+	return func(v *launcher) {
+		v.shutdownSignals = signals
+	}
+}
+
+// WithForceExitOnSecondSignal makes the process exit immediately with exitCode
+// when a shutdown signal is received while graceful shutdown is in progress.
+func WithForceExitOnSecondSignal(exitCode int) Opt {
+	return func(v *launcher) {
+		v.forceExit = true
+		v.forceExitCode = exitCode
+	}
+}
+
+// WithReloadSignals replaces the signals which call reload hooks, SIGHUP by default.
+// The reload signals are caught only when the option is set or a reload hook is added,
+// otherwise they keep the default behaviour, e.g. SIGHUP terminates the process.
+func WithReloadSignals(signals ...os.Signal) Opt {
+	return func(v *launcher) {
+		v.reloadSignals = signals
+	}
+}
+
+func WithReloadHooks(hooks ...ReloadFunc) Opt {
+	return func(v *launcher) {
+		v.reloadHooks = append(v.reloadHooks, hooks...)
+	}
+}
+
+func (c *launcher) OnReload(hook ReloadFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.reloadHooks = append(c.reloadHooks, hook)
+	if c.notifyReload != nil {
+		c.notifyReload()
+		c.notifyReload = nil
+	}
+}
+
+// WithoutOSSignals disables os signals handling, signals can still be sent with Launcher.Signal.
+func WithoutOSSignals() Opt {
+	return func(v *launcher) {
+		v.osSignals = false
+	}
+}
+
+// Signal handles sig the same way as if it was received by the process. It does nothing after Launch returned.
+func (c *launcher) Signal(sig os.Signal) {
+	select {
+	case c.signals <- sig:
+	case <-c.launchDone:
+	}
+}
+
+func (c *launcher) waitForInterruption(ctx context.Context) {
+	shutdownSignals := c.shutdownSignals
+	if len(shutdownSignals) == 0 {
+		shutdownSignals = []os.Signal{syscall.SIGINT, syscall.SIGTERM}
+	}
+	reloadSignals := c.reloadSignals
+	if len(reloadSignals) == 0 {
+		reloadSignals = []os.Signal{syscall.SIGHUP}
+	}
+
+	if c.osSignals {
+		c.subscribe(shutdownSignals, reloadSignals)
+		defer c.unsubscribe()
+	}
+
+	// the hooks are run by the single goroutine, so they do not overlap and do not block signals handling,
+	// the signals received while the hooks are running are merged into one reload
+	reloads := make(chan struct{}, 1)
+	go c.runReloads(ctx, reloads)
+
+	for {
+		select {
+		case <-c.launchDone:
+			return
+		case sig := <-c.signals:
+			switch {
+			case slices.Contains(reloadSignals, sig):
+				select {
+				case reloads <- struct{}{}:
+				default:
+				}
+			case slices.Contains(shutdownSignals, sig):
+				if ctx.Err() == nil {
+					c.cancel()
+					continue
+				}
+				if c.forceExit {
+					c.logger.Error(fmt.Sprintf("received %s during graceful shutdown, exiting immediately", sig))
+					c.exit(c.forceExitCode)
+				}
+			}
+		}
+	}
+}
+
+func (c *launcher) subscribe(shutdownSignals, reloadSignals []os.Signal) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	signal.Notify(c.signals, shutdownSignals...)
+
+	if len(c.reloadSignals) > 0 || len(c.reloadHooks) > 0 {
+		signal.Notify(c.signals, reloadSignals...)
+		return
+	}
+	c.notifyReload = func() {
+		signal.Notify(c.signals, reloadSignals...)
+	}
+}
+
+func (c *launcher) unsubscribe() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.notifyReload = nil
+	signal.Stop(c.signals)
+}
+
+func (c *launcher) runReloads(ctx context.Context, reloads <-chan struct{}) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-reloads:
+			if ctx.Err() == nil {
+				c.reload(ctx)
+			}
+		}
+	}
+}
+
+func (c *launcher) reload(ctx context.Context) {
+	c.mu.Lock()
+	hooks := append([]ReloadFunc(nil), c.reloadHooks...)
+	c.mu.Unlock()
+
+	for _, hook := range hooks {
+		if err := hook(ctx); err != nil {
+			c.logger.Error("reload hook failed", zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package launcher
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func requireCalled(t *testing.T, ch <-chan string, want string) {
+	t.Helper()
+	select {
+	case got := <-ch:
+		require.Equal(t, want, got)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("%s is not called", want)
+	}
+}
+
+func requireNotCalled(t *testing.T, ch <-chan string) {
+	t.Helper()
+	select {
+	case got := <-ch:
+		t.Fatalf("%s is called", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestReloadHooks(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var (
+		calls   = make(chan string, 10)
+		release = make(chan struct{})
+	)
+	l := NewLauncher(WithoutOSSignals(), WithReloadHooks(func(_ context.Context) error {
+		calls <- "first"
+		<-release
+		return nil
+	}))
+	l.AddRunner("server", func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+
+	reportCh := make(chan Report, 1)
+	go func() {
+		reportCh <- l.Launch(context.Background())
+	}()
+	require.NoError(t, l.WaitState(ctx, "server", RunnerStateRunning))
+
+	// the hook added after Launch is called on the next reload
+	l.OnReload(func(_ context.Context) error {
+		calls <- "second"
+		return nil
+	})
+
+	l.Signal(syscall.SIGHUP)
+	requireCalled(t, calls, "first")
+
+	// the hooks do not overlap, the signals received meanwhile are merged into one reload
+	l.Signal(syscall.SIGHUP)
+	l.Signal(syscall.SIGHUP)
+	requireNotCalled(t, calls)
+
+	release <- struct{}{}
+	requireCalled(t, calls, "second")
+	requireCalled(t, calls, "first")
+	requireNotCalled(t, calls)
+
+	// the running hook does not block the shutdown
+	l.Signal(syscall.SIGTERM)
+	select {
+	case report := <-reportCh:
+		require.True(t, report.Clean())
+	case <-ctx.Done():
+		t.Fatal("shutdown is blocked by the reload hook")
+	}
+	close(release)
+}
+
+func TestForceExitOnSecondSignal(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	l := NewLauncher(WithoutOSSignals(), WithForceExitOnSecondSignal(3)).(*launcher)
+	exitCh := make(chan int, 1)
+	l.exit = func(code int) {
+		exitCh <- code
+	}
+
+	release := make(chan struct{})
+	l.AddRunner("server", func(_ context.Context) error {
+		<-release
+		return nil
+	})
+
+	reportCh := make(chan Report, 1)
+	go func() {
+		reportCh <- l.Launch(context.Background())
+	}()
+	require.NoError(t, l.WaitState(ctx, "server", RunnerStateRunning))
+
+	l.Signal(syscall.SIGTERM)
+	select {
+	case code := <-exitCh:
+		t.Fatalf("exited with %d on the first signal", code)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	l.Signal(syscall.SIGINT)
+	select {
+	case code := <-exitCh:
+		require.Equal(t, 3, code)
+	case <-ctx.Done():
+		t.Fatal("second signal did not exit")
+	}
+
+	close(release)
+	require.True(t, (<-reportCh).Clean())
+}
@@ -0,0 +1,152 @@
+package launcher
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// StopFunc gracefully stops the runner, e.g. calls grpc.Server.GracefulStop.
+// The context is cancelled when the runner stop timeout expires.
+type StopFunc func(ctx context.Context) error
+
+// WithStopTimeout sets the time the runner has to finish after its phase is stopped.
+// Graceful timeout of the launcher is extended to fit the longest stop timeout of every phase.
+func WithStopTimeout(timeout time.Duration) RunnerOpt {
+	return func(v *runner) {
+		v.stopTimeout = timeout
+	}
+}
+
+// WithStopFunc sets the function called on shutdown in addition to the runner context cancellation.
+func WithStopFunc(stop StopFunc) RunnerOpt {
+	return func(v *runner) {
+		v.stopFunc = stop
+	}
+}
+
+// WithHardStopFunc sets the function called when the runner did not finish within its stop timeout,
+// e.g. grpc.Server.Stop.
+func WithHardStopFunc(stop func()) RunnerOpt {
+	return func(v *runner) {
+		v.hardStopFunc = stop
+	}
+}
+
+func (r *runner) hasStopHooks() bool {
+	return r.stopFunc != nil || r.hardStopFunc != nil || r.stopTimeout > 0
+}
+
+// gracefulTimeout sums the timeouts of the phases, as they are stopped one by one.
+func (c *launcher) gracefulTimeout() time.Duration {
+	runnables, _ := c.snapshot()
+
+	var timeout time.Duration
+	for _, phase := range groupByPhase(runnables) {
+		phaseTimeout := c.timeout
+		for _, item := range phase {
+			phaseTimeout = max(phaseTimeout, item.stopTimeout)
+		}
+		timeout += phaseTimeout
+	}
+	return max(timeout, c.timeout)
+}
+
+// stopRunners stops the phases in descending order, it returns when the last phase is stopped.
+func (c *launcher) stopRunners() {
+	runnables, _ := c.snapshot()
+
+	phases := groupByPhase(runnables)
+	for i := len(phases) - 1; i >= 0; i-- {
+		var wg sync.WaitGroup
+		for _, item := range phases[i] {
+			if !item.stop() {
+				// the runner was never started, so it will not be
+				continue
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				c.stopRunner(item)
+			}()
+		}
+		wg.Wait()
+	}
+}
+
+func (c *launcher) stopTimeout(item *runner) time.Duration {
+	if item.stopTimeout > 0 {
+		return item.stopTimeout
+	}
+	return c.timeout
+}
+
+func (c *launcher) stopRunner(item *runner) {
+	timeout := c.stopTimeout(item)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if item.stopFunc != nil {
+		go func() {
+			if err := item.stopFunc(ctx); err != nil && ctx.Err() == nil {
+				c.logger.Error(fmt.Sprintf("stopping %s", item.name), zap.String("runner", item.name), zap.Error(err))
+			}
+		}()
+	}
+
+	select {
+	case <-item.doneCh:
+	case <-c.clock.After(timeout):
+		cancel()
+		item.expire()
+		if !item.hasStopHooks() {
+			return
+		}
+		c.logger.Error(
+			fmt.Sprintf("runner %s did not stop in %s", item.name, timeout),
+			zap.String("runner", item.name),
+		)
+		if item.hardStopFunc != nil {
+			item.hardStopFunc()
+		}
+	}
+}
+
+// expireRunner expires the runner stopped by its handle if it did not finish within the stop timeout.
+func (c *launcher) expireRunner(item *runner) {
+	select {
+	case <-item.doneCh:
+	case <-c.clock.After(c.stopTimeout(item)):
+		item.expire()
+	}
+}
+
+func (r *runner) expire() {
+	r.expireOnce.Do(func() {
+		close(r.expiredCh)
+	})
+}
+
+// stopDeadlineContext returns the context which outlives the cancellation of ctx and is cancelled
+// when the stop timeout of the runner owning ctx expires. Outside the launcher ctx is returned as is.
+func stopDeadlineContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	r, ok := ctx.Value(runnerCtxKey{}).(*runner)
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+
+	out, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	go func() {
+		select {
+		case <-r.expiredCh:
+			cancel()
+		case <-out.Done():
+		}
+	}()
+	return out, cancel
+}
@@ -0,0 +1,149 @@
+package launcher_test
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarmalonchik/golibs/launcher"
+	"github.com/tarmalonchik/golibs/launcher/launchertest"
+)
+
+func TestStopFunc(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	l, _ := launchertest.New()
+
+	stopped := make(chan struct{})
+	l.AddRunner("server", func(_ context.Context) error {
+		// the runner ignores the context like http.Server.ListenAndServe does
+		<-stopped
+		return nil
+	}, launcher.WithStopFunc(func(ctx context.Context) error {
+		require.NoError(t, ctx.Err())
+		close(stopped)
+		return nil
+	}))
+
+	reportCh := make(chan launcher.Report, 1)
+	go func() {
+		reportCh <- l.Launch(context.Background())
+	}()
+	require.NoError(t, l.WaitState(ctx, "server", launcher.RunnerStateRunning))
+
+	l.Signal(syscall.SIGTERM)
+	report := <-reportCh
+	require.True(t, report.Clean())
+	require.Equal(t, []string{"server"}, report.Finished)
+}
+
+func TestHardStopFunc(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	l, clock := launchertest.New(launcher.WithTimeout(time.Minute))
+
+	var (
+		stopCtx  = make(chan context.Context, 1)
+		hardStop = make(chan struct{})
+	)
+	l.AddRunner("server", func(_ context.Context) error {
+		<-hardStop
+		return nil
+	}, launcher.WithStopTimeout(5*time.Second), launcher.WithStopFunc(func(ctx context.Context) error {
+		stopCtx <- ctx
+		<-ctx.Done()
+		return ctx.Err()
+	}), launcher.WithHardStopFunc(func() {
+		close(hardStop)
+	}))
+
+	reportCh := make(chan launcher.Report, 1)
+	go func() {
+		reportCh <- l.Launch(context.Background())
+	}()
+	require.NoError(t, l.WaitState(ctx, "server", launcher.RunnerStateRunning))
+
+	l.Signal(syscall.SIGTERM)
+	graceful := <-stopCtx
+
+	// the graceful timeout and the stop timeout of the server
+	require.NoError(t, clock.BlockUntil(ctx, 2))
+	clock.Advance(5*time.Second - time.Millisecond)
+	select {
+	case <-hardStop:
+		t.Fatal("hard stop is called before the stop timeout")
+	case <-time.After(50 * time.Millisecond):
+	}
+	require.NoError(t, graceful.Err())
+
+	clock.Advance(time.Millisecond)
+	<-hardStop
+	<-graceful.Done()
+
+	report := <-reportCh
+	require.True(t, report.Clean())
+	require.Equal(t, 5*time.Second, report.ShutdownDuration)
+}
+
+func TestStopTimeoutFallback(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	l, clock := launchertest.New(launcher.WithTimeout(10 * time.Second))
+
+	var (
+		hardStop = make(chan time.Time, 1)
+		release  = make(chan struct{})
+	)
+	defer close(release)
+
+	// the database has no own stop timeout, so the launcher timeout is used for it
+	l.AddRunner("database", func(_ context.Context) error {
+		<-release
+		return nil
+	}, launcher.WithPhase(0), launcher.WithHardStopFunc(func() {
+		hardStop <- clock.Now()
+	}))
+	l.AddRunner("server", func(ctx context.Context) error {
+		<-ctx.Done()
+		<-release
+		return nil
+	}, launcher.WithPhase(1), launcher.WithStopTimeout(20*time.Second), launcher.WithHardStopFunc(func() {}))
+
+	reportCh := make(chan launcher.Report, 1)
+	go func() {
+		reportCh <- l.Launch(context.Background())
+	}()
+	require.NoError(t, l.WaitState(ctx, "database", launcher.RunnerStateRunning))
+	require.NoError(t, l.WaitState(ctx, "server", launcher.RunnerStateRunning))
+
+	start := clock.Now()
+	l.Signal(syscall.SIGTERM)
+
+	// the graceful timeout is the sum of the phase timeouts: 20s of the server and 10s of the database
+	require.NoError(t, clock.BlockUntil(ctx, 2))
+	clock.Advance(20 * time.Second)
+	require.NoError(t, clock.BlockUntil(ctx, 2))
+	clock.Advance(10*time.Second - time.Millisecond)
+
+	select {
+	case <-reportCh:
+		t.Fatal("launch returned before the graceful timeout")
+	case <-hardStop:
+		t.Fatal("hard stop is called before the stop timeout")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	clock.Advance(time.Millisecond)
+	require.Equal(t, start.Add(30*time.Second), <-hardStop)
+
+	report := <-reportCh
+	require.True(t, report.TimedOut)
+	require.ElementsMatch(t, []string{"database", "server"}, report.Unfinished)
+	require.Equal(t, 30*time.Second, report.ShutdownDuration)
+}
@@ -0,0 +1,39 @@
+//go:generate go-enum -f=$GOFILE --nocase --values
+package launcher
+
+import "time"
+
+// RunnerState
+// ENUM(
+// pending
+// running
+// restarting
+// scheduled
+// finished
+// failed
+// )
+type RunnerState string
+
+type RunnerStatus struct {
+	Name      string
+	Labels    map[string]string
+	State     RunnerState
+	Ready     bool
+	Restarts  int
+	LastError error
+	LastStart time.Time
+}
+
+// BudgetAction
+// ENUM(
+// give_up
+// cancel_all
+// )
+type BudgetAction string
+
+// OverlapPolicy
+// ENUM(
+// skip
+// queue
+// )
+type OverlapPolicy string
@@ -0,0 +1,36 @@
+package logger
+
+import (
+	"context"
+	"runtime"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// write logs the entry coming from the other logging libraries. The caller is taken from pc,
+// fatal and panic entries are written as errors, the exit is up to the library.
+func (l *Logger) write(ctx context.Context, lvl Level, pc uintptr, msg string, fields []zap.Field) {
+	fields = l.contextFields(ctx, fields)
+
+	zapLvl := toZapLevel(lvl)
+	if zapLvl > zapcore.ErrorLevel {
+		zapLvl = zapcore.ErrorLevel
+	}
+
+	if ce := l.log.Check(zapLvl, msg); ce != nil {
+		ce.Caller = zapcore.EntryCaller{}
+		if pc != 0 {
+			frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+			ce.Caller = zapcore.NewEntryCaller(frame.PC, frame.File, frame.Line, true)
+		}
+		ce.Write(fields...)
+	}
+
+	l.runSenders(lvl, msg, fields...)
+}
+
+// enabled reports whether the entry of the level is written, it is used by the bridges to skip the work early.
+func (l *Logger) enabled(lvl Level) bool {
+	return l.log.Core().Enabled(toZapLevel(lvl))
+}
@@ -0,0 +1,109 @@
+package logger
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/tarmalonchik/golibs/grpc/middleware"
+)
+
+const (
+	requestIDMetadataKey = "x-request-id"
+)
+
+type (
+	loggerCtxKey    struct{}
+	fieldsCtxKey    struct{}
+	requestIDCtxKey struct{}
+	traceIDCtxKey   struct{}
+)
+
+// ContextExtractor returns fields which should be added to every log line made with the context.
+type ContextExtractor func(ctx context.Context) []zap.Field
+
+var defaultLogger = sync.OnceValue(func() *Logger {
+	return NewLogger(WithLevel(LevelInfo))
+})
+
+// ToContext stores the logger in the context.
+func ToContext(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey{}, l)
+}
+
+// FromContext returns the logger stored with ToContext or the default info logger.
+func FromContext(ctx context.Context) *Logger {
+	if l, ok := ctx.Value(loggerCtxKey{}).(*Logger); ok && l != nil {
+		return l
+	}
+	return defaultLogger()
+}
+
+// ContextWithFields adds request-scoped fields to the context, they are logged by every *Ctx call.
+func ContextWithFields(ctx context.Context, fields ...zap.Field) context.Context {
+	prev, _ := ctx.Value(fieldsCtxKey{}).([]zap.Field)
+	return context.WithValue(ctx, fieldsCtxKey{}, slices.Concat(prev, fields))
+}
+
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDCtxKey{}, requestID)
+}
+
+func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDCtxKey{}, traceID)
+}
+
+func defaultContextFields(ctx context.Context) []zap.Field {
+	var out []zap.Field
+
+	if traceID, ok := ctx.Value(traceIDCtxKey{}).(string); ok && traceID != "" {
+		out = append(out, zap.String("trace_id", traceID))
+	}
+
+	if requestID := requestIDFromContext(ctx); requestID != "" {
+		out = append(out, zap.String("request_id", requestID))
+	}
+
+	if method, ok := grpc.Method(ctx); ok {
+		out = append(out, zap.String("grpc_method", method))
+	}
+
+	if username, ok := ctx.Value(middleware.ContextKeyUsername).(string); ok && username != "" {
+		out = append(out, zap.String("username", username))
+	}
+
+	if fields, ok := ctx.Value(fieldsCtxKey{}).([]zap.Field); ok {
+		out = append(out, fields...)
+	}
+
+	return out
+}
+
+func requestIDFromContext(ctx context.Context) string {
+	if requestID, ok := ctx.Value(requestIDCtxKey{}).(string); ok && requestID != "" {
+		return requestID
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDMetadataKey); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+func (l *Logger) contextFields(ctx context.Context, fields []zap.Field) []zap.Field {
+	if ctx == nil {
+		return fields
+	}
+
+	out := defaultContextFields(ctx)
+	for i := range l.o.extractors {
+		out = append(out, l.o.extractors[i](ctx)...)
+	}
+	return append(out, fields...)
+}
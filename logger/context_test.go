@@ -0,0 +1,98 @@
+package logger_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/tarmalonchik/golibs/grpc/middleware"
+	"github.com/tarmalonchik/golibs/logger"
+	"github.com/tarmalonchik/golibs/logger/loggertest"
+)
+
+type tenantCtxKey struct{}
+
+type methodStream struct {
+	grpc.ServerTransportStream
+	method string
+}
+
+func (s methodStream) Method() string {
+	return s.method
+}
+
+func Test_ContextFields(t *testing.T) {
+	tenant := func(ctx context.Context) []zap.Field {
+		if v, ok := ctx.Value(tenantCtxKey{}).(string); ok {
+			return []zap.Field{zap.String("tenant", v)}
+		}
+		return nil
+	}
+	incoming := func(requestID string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", requestID))
+	}
+
+	for _, tc := range []struct {
+		name string
+		ctx  context.Context
+		want map[string]any
+	}{
+		{
+			name: "empty",
+			ctx:  context.Background(),
+			want: map[string]any{"attempt": int64(1)},
+		},
+		{
+			name: "trace id",
+			ctx:  logger.ContextWithTraceID(context.Background(), "trace-1"),
+			want: map[string]any{"trace_id": "trace-1", "attempt": int64(1)},
+		},
+		{
+			name: "request id from metadata",
+			ctx:  incoming("req-md"),
+			want: map[string]any{"request_id": "req-md", "attempt": int64(1)},
+		},
+		{
+			name: "request id from context wins",
+			ctx:  logger.ContextWithRequestID(incoming("req-md"), "req-ctx"),
+			want: map[string]any{"request_id": "req-ctx", "attempt": int64(1)},
+		},
+		{
+			name: "grpc method",
+			ctx:  grpc.NewContextWithServerTransportStream(context.Background(), methodStream{method: "/svc.Orders/Get"}),
+			want: map[string]any{"grpc_method": "/svc.Orders/Get", "attempt": int64(1)},
+		},
+		{
+			name: "username",
+			ctx:  context.WithValue(context.Background(), middleware.ContextKeyUsername, "admin"),
+			want: map[string]any{"username": "admin", "attempt": int64(1)},
+		},
+		{
+			name: "chained fields",
+			ctx: logger.ContextWithFields(
+				logger.ContextWithFields(context.Background(), zap.String("order", "42")),
+				zap.Int("user", 7),
+			),
+			want: map[string]any{"order": "42", "user": int64(7), "attempt": int64(1)},
+		},
+		{
+			name: "custom extractor",
+			ctx:  context.WithValue(context.Background(), tenantCtxKey{}, "acme"),
+			want: map[string]any{"tenant": "acme", "attempt": int64(1)},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			l, logs := loggertest.New(logger.WithContextExtractor(tenant))
+
+			l.InfoCtx(tc.ctx, "handled", zap.Int("attempt", 1))
+
+			entries := logs.Filter(loggertest.Message("handled"))
+			require.Len(t, entries, 1)
+			require.Equal(t, tc.want, entries[0].ContextMap())
+		})
+	}
+}
@@ -0,0 +1,126 @@
+//go:generate go-enum -f=$GOFILE --nocase --values
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// Encoding
+// ENUM(
+// json
+// console
+// logfmt
+// )
+type Encoding string
+
+func newEncoder(encoding Encoding) zapcore.Encoder {
+	cfg := zapcore.EncoderConfig{
+		TimeKey:        "ts",
+		LevelKey:       "level",
+		NameKey:        "logger",
+		CallerKey:      "caller",
+		MessageKey:     "msg",
+		StacktraceKey:  "stacktrace",
+		LineEnding:     zapcore.DefaultLineEnding,
+		EncodeLevel:    zapcore.LowercaseLevelEncoder,
+		EncodeTime:     zapcore.ISO8601TimeEncoder,
+		EncodeDuration: zapcore.StringDurationEncoder,
+		EncodeCaller:   zapcore.ShortCallerEncoder,
+	}
+
+	switch encoding {
+	case EncodingConsole:
+		cfg.EncodeLevel = zapcore.CapitalLevelEncoder
+		return zapcore.NewConsoleEncoder(cfg)
+	case EncodingLogfmt:
+		return &logfmtEncoder{MapObjectEncoder: zapcore.NewMapObjectEncoder(), cfg: cfg}
+	default:
+		return zapcore.NewJSONEncoder(cfg)
+	}
+}
+
+var logfmtPool = buffer.NewPool()
+
+// logfmtEncoder writes the entries as key=value pairs, the fields are sorted by key.
+type logfmtEncoder struct {
+	*zapcore.MapObjectEncoder
+	cfg zapcore.EncoderConfig
+}
+
+func (e *logfmtEncoder) Clone() zapcore.Encoder {
+	clone := zapcore.NewMapObjectEncoder()
+	maps.Copy(clone.Fields, e.Fields)
+	return &logfmtEncoder{MapObjectEncoder: clone, cfg: e.cfg}
+}
+
+func (e *logfmtEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	enc := e.Clone().(*logfmtEncoder)
+	for i := range fields {
+		fields[i].AddTo(enc)
+	}
+
+	buf := logfmtPool.Get()
+	writePair(buf, e.cfg.TimeKey, ent.Time.Format(time.RFC3339Nano))
+	writePair(buf, e.cfg.LevelKey, ent.Level.String())
+	if ent.LoggerName != "" {
+		writePair(buf, e.cfg.NameKey, ent.LoggerName)
+	}
+	if ent.Caller.Defined {
+		writePair(buf, e.cfg.CallerKey, ent.Caller.TrimmedPath())
+	}
+	writePair(buf, e.cfg.MessageKey, ent.Message)
+
+	for _, key := range slices.Sorted(maps.Keys(enc.Fields)) {
+		writePair(buf, key, logfmtValue(enc.Fields[key]))
+	}
+
+	if ent.Stack != "" {
+		writePair(buf, e.cfg.StacktraceKey, ent.Stack)
+	}
+	buf.AppendString(e.cfg.LineEnding)
+
+	return buf, nil
+}
+
+func writePair(buf *buffer.Buffer, key, value string) {
+	if buf.Len() > 0 {
+		buf.AppendByte(' ')
+	}
+	buf.AppendString(key)
+	buf.AppendByte('=')
+
+	if value == "" || strings.ContainsAny(value, " =\"\t\r\n") {
+		buf.AppendString(strconv.Quote(value))
+		return
+	}
+	buf.AppendString(value)
+}
+
+func logfmtValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	case error:
+		return v.Error()
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr, float32, float64:
+		return fmt.Sprint(v)
+	default:
+		// objects, arrays and reflected values
+		out, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(out)
+	}
+}
@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type levelRequest struct {
+	Logger string `json:"logger,omitempty"`
+	Level  Level  `json:"level"`
+}
+
+type levelResponse struct {
+	Level   Level            `json:"level"`
+	Loggers map[string]Level `json:"loggers,omitempty"`
+}
+
+// LevelHandler returns http handler to inspect and change levels in runtime.
+//
+//	GET                                      returns the root level and named overrides
+//	PUT {"level":"debug"}                    changes the root level
+//	PUT {"logger":"kafka","level":"debug"}   overrides the level of the named logger
+//	DELETE ?logger=kafka                     removes the override
+func (l *Logger) LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut, http.MethodPost:
+			var req levelRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, "invalid body: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			lvl, err := ParseLevel(req.Level.String())
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			l.levels.set(req.Logger, toZapLevel(lvl))
+		case http.MethodDelete:
+			name := r.URL.Query().Get("logger")
+			if name == "" {
+				http.Error(w, "logger is required", http.StatusBadRequest)
+				return
+			}
+			l.levels.reset(name)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		resp := levelResponse{
+			Level:   fromZapLevel(l.levels.root.Level()),
+			Loggers: make(map[string]Level),
+		}
+		for name, lvl := range l.levels.overrides() {
+			resp.Loggers[name] = fromZapLevel(lvl)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+}
@@ -0,0 +1,123 @@
+package logger
+
+import (
+	"maps"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// levelRegistry holds the root level and per named logger overrides shared by all children of a logger.
+type levelRegistry struct {
+	root zap.AtomicLevel
+
+	mu    sync.RWMutex
+	named map[string]zapcore.Level
+}
+
+func newLevelRegistry(lvl zapcore.Level) *levelRegistry {
+	return &levelRegistry{
+		root:  zap.NewAtomicLevelAt(lvl),
+		named: make(map[string]zapcore.Level),
+	}
+}
+
+// level returns the override of the closest named parent ("a.b" then "a") or the root level.
+func (r *levelRegistry) level(name string) zapcore.Level {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for name != "" {
+		if lvl, ok := r.named[name]; ok {
+			return lvl
+		}
+		idx := strings.LastIndexByte(name, '.')
+		if idx < 0 {
+			break
+		}
+		name = name[:idx]
+	}
+	return r.root.Level()
+}
+
+func (r *levelRegistry) set(name string, lvl zapcore.Level) {
+	if name == "" {
+		r.root.SetLevel(lvl)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.named[name] = lvl
+}
+
+func (r *levelRegistry) reset(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.named, name)
+}
+
+func (r *levelRegistry) overrides() map[string]zapcore.Level {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return maps.Clone(r.named)
+}
+
+// levelCore filters entries by the level of the named logger, the wrapped core accepts everything.
+type levelCore struct {
+	zapcore.Core
+	registry *levelRegistry
+	name     string
+}
+
+func (c *levelCore) Enabled(lvl zapcore.Level) bool {
+	return c.registry.level(c.name).Enabled(lvl)
+}
+
+func (c *levelCore) Level() zapcore.Level {
+	return c.registry.level(c.name)
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{
+		Core:     c.Core.With(fields),
+		registry: c.registry,
+		name:     c.name,
+	}
+}
+
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
+
+func (c *levelCore) named(name string) *levelCore {
+	return &levelCore{
+		Core:     c.Core,
+		registry: c.registry,
+		name:     name,
+	}
+}
+
+func toZapLevel(lvl Level) zapcore.Level {
+	zapLvl, err := zapcore.ParseLevel(lvl.String())
+	if err != nil {
+		panic("invalid level: " + err.Error())
+	}
+	return zapLvl
+}
+
+func fromZapLevel(lvl zapcore.Level) Level {
+	if lvl == zapcore.DPanicLevel {
+		return LevelPanic
+	}
+	out, err := ParseLevel(lvl.String())
+	if err != nil {
+		return LevelInfo
+	}
+	return out
+}
@@ -2,15 +2,27 @@ package logger
 
 import (
 	"context"
+	"io"
+	"os"
+	"slices"
+	"time"
 
 	"github.com/tarmalonchik/golibs/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
+const fatalFlushTimeout = 5 * time.Second
+
 type Logger struct {
-	log *zap.Logger
-	o   *options
+	log    *zap.Logger
+	o      *options
+	fields []zap.Field
+	name   string
+	levels *levelRegistry
+	pipe   *pipeline
+	sinks  []zapcore.WriteSyncer
+	redact *redactor
 }
 
 func NewLogger(opts ...Opt) *Logger {
@@ -21,39 +33,130 @@ func NewLogger(opts ...Opt) *Logger {
 		opt(l.o)
 	}
 
-	var err error
-	var cfg zap.Config
-
-	if l.GetLevel() == LevelInfo || l.GetLevel() == LevelDebug || l.GetLevel() == LevelWarn {
-		cfg = zap.NewDevelopmentConfig()
-	} else {
-		cfg = zap.NewProductionConfig()
-	}
-
 	zapLvl, err := zapcore.ParseLevel(l.o.level.String())
 	if err != nil {
 		panic("create logger lvl: " + err.Error())
 	}
 
-	cfg.Level = zap.NewAtomicLevel()
-	cfg.Level.SetLevel(zapLvl)
+	if len(l.o.sinks) == 0 {
+		l.o.sinks = []Sink{StderrSink()}
+	}
 
-	if l.log, err = cfg.Build(); err != nil {
-		panic("build logger: " + err.Error())
+	syncers := make([]zapcore.WriteSyncer, 0, len(l.o.sinks))
+	for _, sink := range l.o.sinks {
+		ws, err := sink()
+		if err != nil {
+			panic("open sink: " + err.Error())
+		}
+		syncers = append(syncers, ws)
 	}
 
-	l.log = l.log.WithOptions(zap.AddCallerSkip(1))
+	// the levels are checked by levelCore, so the level could be changed in runtime per named logger
+	l.levels = newLevelRegistry(zapLvl)
+
+	cores := append([]zapcore.Core{
+		zapcore.NewCore(newEncoder(l.o.encoding), zapcore.NewMultiWriteSyncer(syncers...), zapcore.DebugLevel),
+	}, l.o.cores...)
+	// every core is wrapped on its own, so the tee still checks the levels of the cores added with WithCore
+	if l.redact = newRedactor(l.o.redactKeys, l.o.redactRules); l.redact != nil {
+		for i := range cores {
+			cores[i] = &redactCore{Core: cores[i], r: l.redact}
+		}
+	}
+	core := zapcore.NewTee(cores...)
+	if s := l.o.sampling; s != nil {
+		core = zapcore.NewSamplerWithOptions(core, s.tick, s.first, s.thereafter)
+	}
+
+	l.log = zap.New(&levelCore{Core: core, registry: l.levels},
+		zap.AddCaller(),
+		zap.AddCallerSkip(1),
+		zap.AddStacktrace(zapcore.ErrorLevel),
+		zap.ErrorOutput(zapcore.Lock(os.Stderr)),
+		zap.Fields(l.o.staticFields...),
+	)
+	l.sinks = syncers
+	l.pipe = newPipeline(l.o.senders)
 
 	return l
 }
 
-func (l *Logger) Close(_ context.Context) error {
+// With returns a child logger which adds fields to every log line and sender call.
+func (l *Logger) With(fields ...zap.Field) *Logger {
+	return &Logger{
+		log:    l.log.With(fields...),
+		o:      l.o,
+		fields: slices.Concat(l.fields, fields),
+		name:   l.name,
+		levels: l.levels,
+		pipe:   l.pipe,
+		sinks:  l.sinks,
+		redact: l.redact,
+	}
+}
+
+// Named returns a child logger with the name appended to the current one with a dot.
+// The level of named logger could be overridden with SetLevel or SetNamedLevel.
+func (l *Logger) Named(name string) *Logger {
+	fullName := name
+	if l.name != "" {
+		fullName = l.name + "." + name
+	}
+
+	rename := zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		if lc, ok := core.(*levelCore); ok {
+			return lc.named(fullName)
+		}
+		return core
+	})
+
+	return &Logger{
+		log:    l.log.Named(name).WithOptions(rename),
+		o:      l.o,
+		fields: l.fields,
+		name:   fullName,
+		levels: l.levels,
+		pipe:   l.pipe,
+		sinks:  l.sinks,
+		redact: l.redact,
+	}
+}
+
+// SetLevel changes the level of the logger in runtime. For the named logger it sets the override of its name.
+func (l *Logger) SetLevel(lvl Level) {
+	l.levels.set(l.name, toZapLevel(lvl))
+}
+
+// SetNamedLevel overrides the level of the named logger and its children.
+func (l *Logger) SetNamedLevel(name string, lvl Level) {
+	l.levels.set(name, toZapLevel(lvl))
+}
+
+// ResetNamedLevel removes the override, the named logger uses the level of its parent again.
+func (l *Logger) ResetNamedLevel(name string) {
+	l.levels.reset(name)
+}
+
+// Close flushes the entries queued for the senders, new entries are not sent after Close.
+func (l *Logger) Close(ctx context.Context) error {
+	err := l.pipe.close(ctx)
 	_ = l.log.Sync()
-	return nil
+
+	for _, sink := range l.sinks {
+		if closer, ok := sink.(io.Closer); ok {
+			_ = closer.Close()
+		}
+	}
+	return err
+}
+
+// Dropped returns the count of entries dropped because of the full sender queues.
+func (l *Logger) Dropped() uint64 {
+	return l.pipe.dropped()
 }
 
 func (l *Logger) GetLevel() Level {
-	return l.o.level
+	return fromZapLevel(l.levels.level(l.name))
 }
 
 func (l *Logger) Info(msg string, fields ...zap.Field) {
@@ -78,14 +181,69 @@ func (l *Logger) Debug(msg string, fields ...zap.Field) {
 }
 
 func (l *Logger) Fatal(msg string, fields ...zap.Field) {
+	l.flushSenders(msg, fields...)
+	l.log.Fatal(msg, fields...)
+}
+
+func (l *Logger) InfoCtx(ctx context.Context, msg string, fields ...zap.Field) {
+	fields = l.contextFields(ctx, fields)
+	l.log.Info(msg, fields...)
+	l.runSenders(LevelInfo, msg, fields...)
+}
+
+func (l *Logger) WarnCtx(ctx context.Context, msg string, fields ...zap.Field) {
+	fields = l.contextFields(ctx, fields)
+	l.log.Warn(msg, fields...)
+	l.runSenders(LevelWarn, msg, fields...)
+}
+
+func (l *Logger) ErrorCtx(ctx context.Context, msg string, fields ...zap.Field) {
+	fields = l.contextFields(ctx, fields)
+	l.log.Error(msg, fields...)
+	fields = append(fields, zap.String("stacktrace", trace.FuncNameWithSkip(3).Error()))
+	l.runSenders(LevelError, msg, fields...)
+}
+
+func (l *Logger) DebugCtx(ctx context.Context, msg string, fields ...zap.Field) {
+	fields = l.contextFields(ctx, fields)
+	l.log.Debug(msg, fields...)
+	l.runSenders(LevelDebug, msg, fields...)
+}
+
+func (l *Logger) FatalCtx(ctx context.Context, msg string, fields ...zap.Field) {
+	fields = l.contextFields(ctx, fields)
+	l.flushSenders(msg, fields...)
 	l.log.Fatal(msg, fields...)
-	l.runSenders(LevelFatal, msg, fields...)
 }
 
 func (l *Logger) runSenders(lvl Level, msg string, fields ...zap.Field) {
-	go func() {
-		for i := range l.o.senders {
-			l.o.senders[i](lvl, msg, fields...)
-		}
-	}()
+	l.send(context.Background(), lvl, msg, fields...)
+}
+
+// send queues the entry for the senders, ctx limits the wait for the blocking queues.
+func (l *Logger) send(ctx context.Context, lvl Level, msg string, fields ...zap.Field) {
+	if len(l.pipe.queues) == 0 {
+		return
+	}
+
+	fields = slices.Concat(l.fields, fields)
+	if l.redact != nil {
+		msg = l.redact.value(msg)
+		fields = l.redact.fields(fields)
+	}
+
+	l.pipe.push(ctx, Entry{
+		Time:    time.Now(),
+		Level:   lvl,
+		Message: msg,
+		Fields:  fields,
+	})
+}
+
+// flushSenders gives the senders a chance to deliver the fatal entry before exit.
+func (l *Logger) flushSenders(msg string, fields ...zap.Field) {
+	ctx, cancel := context.WithTimeout(context.Background(), fatalFlushTimeout)
+	defer cancel()
+	l.send(ctx, LevelFatal, msg, fields...)
+	_ = l.pipe.close(ctx)
 }
@@ -1,10 +1,25 @@
 package logger
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func Test_Level(t *testing.T) {
@@ -13,3 +28,251 @@ func Test_Level(t *testing.T) {
 		require.NoError(t, err)
 	}
 }
+
+func Test_NamedLevel(t *testing.T) {
+	l := NewLogger(WithLevel(LevelInfo))
+	kafka := l.Named("kafka")
+	consumer := kafka.Named("consumer")
+
+	l.SetNamedLevel("kafka", LevelDebug)
+	require.Equal(t, LevelInfo, l.GetLevel())
+	require.Equal(t, LevelDebug, consumer.GetLevel())
+
+	consumer.SetLevel(LevelError)
+	require.Equal(t, LevelError, consumer.GetLevel())
+	require.Equal(t, LevelDebug, kafka.GetLevel())
+
+	l.ResetNamedLevel("kafka")
+	l.SetLevel(LevelWarn)
+	require.Equal(t, LevelWarn, kafka.GetLevel())
+	require.Equal(t, LevelError, consumer.GetLevel())
+}
+
+func Test_LevelHandler(t *testing.T) {
+	l := NewLogger(WithLevel(LevelInfo))
+	h := l.LevelHandler()
+
+	serve := func(method, target, body string) (int, levelResponse) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+
+		var resp levelResponse
+		if rec.Code == http.StatusOK {
+			require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		}
+		return rec.Code, resp
+	}
+
+	code, resp := serve(http.MethodGet, "/", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, levelResponse{Level: LevelInfo}, resp)
+
+	code, resp = serve(http.MethodPut, "/", `{"level":"warn"}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, LevelWarn, resp.Level)
+	require.Equal(t, LevelWarn, l.GetLevel())
+
+	code, resp = serve(http.MethodPost, "/", `{"logger":"kafka","level":"debug"}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, levelResponse{Level: LevelWarn, Loggers: map[string]Level{"kafka": LevelDebug}}, resp)
+	require.Equal(t, LevelDebug, l.Named("kafka").GetLevel())
+
+	code, resp = serve(http.MethodDelete, "/?logger=kafka", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, levelResponse{Level: LevelWarn}, resp)
+	require.Equal(t, LevelWarn, l.Named("kafka").GetLevel())
+
+	for _, tc := range []struct {
+		name   string
+		method string
+		target string
+		body   string
+		code   int
+	}{
+		{name: "invalid json", method: http.MethodPut, target: "/", body: `{"level":`, code: http.StatusBadRequest},
+		{name: "unknown level", method: http.MethodPut, target: "/", body: `{"level":"verbose"}`, code: http.StatusBadRequest},
+		{name: "delete without logger", method: http.MethodDelete, target: "/", code: http.StatusBadRequest},
+		{name: "unsupported method", method: http.MethodPatch, target: "/", body: `{"level":"debug"}`, code: http.StatusMethodNotAllowed},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			code, _ := serve(tc.method, tc.target, tc.body)
+			require.Equal(t, tc.code, code)
+			require.Equal(t, LevelWarn, l.GetLevel())
+		})
+	}
+}
+
+func Test_SenderQueue(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		batches [][]Entry
+	)
+
+	l := NewLogger(
+		WithLevel(LevelError),
+		WithBatchSender(func(entries []Entry) {
+			mu.Lock()
+			defer mu.Unlock()
+			batches = append(batches, slices.Clone(entries))
+		}, WithMinLevel(LevelWarn), WithBatching(2, time.Hour), WithOverflowPolicy(OverflowPolicyBlock)),
+	)
+
+	l.Debug("skipped")
+	l.Warn("first")
+	l.Error("second")
+	l.Warn("third")
+	require.NoError(t, l.Close(context.Background()))
+	l.Warn("after close")
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, batches, 2)
+	require.Equal(t, "first", batches[0][0].Message)
+	require.Equal(t, "second", batches[0][1].Message)
+	require.Equal(t, "third", batches[1][0].Message)
+}
+
+func Test_SenderQueueCloseDeadline(t *testing.T) {
+	var (
+		hang    = make(chan struct{})
+		sending = make(chan struct{}, 1)
+	)
+	defer close(hang)
+
+	l := NewLogger(
+		WithLevel(LevelError),
+		WithSender(func(Level, string, ...zap.Field) {
+			sending <- struct{}{}
+			<-hang
+		},
+			WithQueueSize(1), WithOverflowPolicy(OverflowPolicyBlock)),
+	)
+
+	logged := make(chan struct{})
+	go func() {
+		defer close(logged)
+		for range 3 {
+			l.Warn("blocked")
+		}
+	}()
+	<-sending
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	require.ErrorIs(t, l.Close(ctx), context.DeadlineExceeded)
+	require.Less(t, time.Since(started), time.Second)
+
+	select {
+	case <-logged:
+	case <-time.After(time.Second):
+		t.Fatal("log call is still blocked after Close")
+	}
+}
+
+func Test_FileSinkRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	other := filepath.Join(filepath.Dir(path), "app-worker.log")
+	require.NoError(t, os.WriteFile(other, []byte("worker\n"), 0o644))
+
+	l := NewLogger(
+		WithEncoding(EncodingLogfmt),
+		WithSinks(FileSink(path, WithMaxSize(1), WithMaxBackups(2), WithCompress())),
+		WithServiceInfo("billing", "v1.2.3"),
+	)
+
+	payload := strings.Repeat("x", 300*1024)
+	for range 10 {
+		l.Info("big entry", zap.String("payload", payload))
+	}
+	l.Info("last entry", zap.Int("attempt", 3))
+	require.NoError(t, l.Close(context.Background()))
+
+	backups, err := filepath.Glob(filepath.Join(filepath.Dir(path), "app-*.log.gz"))
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+	require.FileExists(t, other)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `level=info`)
+	require.Contains(t, string(data), `msg="last entry" attempt=3`)
+	require.Contains(t, string(data), `service=billing`)
+}
+
+func Test_Bridges(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		messages []string
+	)
+
+	l := NewLogger(WithLevel(LevelInfo), WithSender(func(lvl Level, msg string, fields ...zap.Field) {
+		mu.Lock()
+		defer mu.Unlock()
+		messages = append(messages, lvl.String()+" "+msg)
+	}))
+
+	log := slog.New(l.SlogHandler()).WithGroup("req")
+	log.Debug("slog debug")
+	log.Info("slog info", "id", 1)
+
+	lr := l.Logrus()
+	lr.Debug("logrus debug")
+	lr.WithField("id", 2).Warn("logrus warn")
+
+	require.NoError(t, l.Close(context.Background()))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []string{"info slog info", "warn logrus warn"}, messages)
+}
+
+func Test_Redaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	var entries []Entry
+	l := NewLogger(
+		WithSinks(FileSink(path)),
+		WithDefaultRedaction(),
+		WithBatchSender(func(batch []Entry) { entries = append(entries, batch...) }),
+	)
+
+	l.With(zap.String("Authorization", "Basic dXNlcjpwYXNz")).Info(
+		"get https://api.telegram.org/bot123456789:AAHdqTcvCH1vGWJxfSeofSAs0K5PALDsaw/getMe",
+		zap.String("card", "paid with 4111 1111 1111 1111"),
+		zap.String("order", "1234567890123"),
+		zap.Any("headers", map[string]any{"token": "abc", "x-request-id": "Bearer abc.def"}),
+		zap.Error(errors.New("password=qwerty")),
+	)
+	require.NoError(t, l.Close(context.Background()))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, `{"level":"info","msg":"get https://api.telegram.org/bot[masked]/getMe",`+
+		`"Authorization":"[masked]","card":"paid with [masked]","order":"1234567890123",`+
+		`"headers":{"token":"[masked]","x-request-id":"Bearer [masked]"},"error":"password=qwerty"}`+"\n",
+		stripTimeAndCaller(data))
+
+	require.Len(t, entries, 1)
+	require.Equal(t, "get https://api.telegram.org/bot[masked]/getMe", entries[0].Message)
+	require.Equal(t, "[masked]", entries[0].Fields[0].String)
+}
+
+func Test_RedactionKeepsCoreLevels(t *testing.T) {
+	core, logs := observer.New(zapcore.ErrorLevel)
+	l := NewLogger(WithLevel(LevelDebug), WithSinks(DiscardSink()), WithCore(core), WithDefaultRedaction())
+
+	l.Info("skipped")
+	l.Error("failed", zap.String("token", "abc"))
+
+	require.Equal(t, 1, logs.Len())
+	require.Equal(t, "failed", logs.All()[0].Message)
+	require.Equal(t, "[masked]", logs.All()[0].ContextMap()["token"])
+}
+
+func stripTimeAndCaller(line []byte) string {
+	out := regexp.MustCompile(`"(ts|caller)":"[^"]*",`).ReplaceAll(line, nil)
+	return string(out)
+}
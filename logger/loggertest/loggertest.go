@@ -0,0 +1,22 @@
+package loggertest
+
+import (
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/tarmalonchik/golibs/logger"
+)
+
+// New creates a debug logger which keeps the log lines in memory instead of writing them,
+// the options could override the level or add senders.
+func New(opts ...logger.Opt) (*logger.Logger, *Logs) {
+	core, observed := observer.New(zapcore.DebugLevel)
+
+	opts = append([]logger.Opt{
+		logger.WithLevel(logger.LevelDebug),
+		logger.WithSinks(logger.DiscardSink()),
+		logger.WithCore(core),
+	}, opts...)
+
+	return logger.NewLogger(opts...), &Logs{observed: observed}
+}
@@ -0,0 +1,34 @@
+package loggertest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tarmalonchik/golibs/logger"
+)
+
+func TestLogs(t *testing.T) {
+	l, logs := New(logger.WithLevel(logger.LevelInfo))
+
+	l.Debug("skipped")
+	l.Named("kafka").With(zap.String("topic", "orders")).Error("consume failed", zap.Error(errors.New("eof")), zap.Int("attempt", 3))
+	l.Info("consumed")
+
+	require.Len(t, logs.All(), 2)
+	require.True(t, logs.Contains(
+		Level(logger.LevelError),
+		Named("kafka"),
+		Message("consume failed"),
+		Field("topic", "orders"),
+		Field("attempt", 3),
+		Field("error", errors.New("eof")),
+	))
+	require.Equal(t, 1, logs.Count(MessageContains("consum"), Level(logger.LevelInfo)))
+	require.False(t, logs.Contains(HasField("topic"), Level(logger.LevelInfo)))
+
+	logs.Reset()
+	require.Empty(t, logs.All())
+}
@@ -0,0 +1,122 @@
+package loggertest
+
+import (
+	"reflect"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/tarmalonchik/golibs/logger"
+)
+
+type Entry = observer.LoggedEntry
+
+// Filter selects the entries, all filters passed to the query should match.
+type Filter func(e Entry) bool
+
+type Logs struct {
+	observed *observer.ObservedLogs
+}
+
+// All returns all entries in order of logging.
+func (l *Logs) All() []Entry {
+	return l.observed.All()
+}
+
+// Filter returns the entries matching all filters.
+func (l *Logs) Filter(filters ...Filter) []Entry {
+	var out []Entry
+	for _, e := range l.observed.All() {
+		if match(e, filters) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Count returns the count of the entries matching all filters.
+func (l *Logs) Count(filters ...Filter) int {
+	return len(l.Filter(filters...))
+}
+
+// Contains reports whether any entry matches all filters.
+func (l *Logs) Contains(filters ...Filter) bool {
+	return l.Count(filters...) > 0
+}
+
+// Reset removes the entries logged so far.
+func (l *Logs) Reset() {
+	_ = l.observed.TakeAll()
+}
+
+func match(e Entry, filters []Filter) bool {
+	for _, f := range filters {
+		if !f(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// Level selects the entries of the level, fatal and panic entries are written by the bridges as errors.
+func Level(lvl logger.Level) Filter {
+	return func(e Entry) bool {
+		return e.Level.String() == zapLevel(lvl).String()
+	}
+}
+
+// Message selects the entries with the exact message.
+func Message(msg string) Filter {
+	return func(e Entry) bool {
+		return e.Message == msg
+	}
+}
+
+// MessageContains selects the entries with the message containing sub.
+func MessageContains(sub string) Filter {
+	return func(e Entry) bool {
+		return strings.Contains(e.Message, sub)
+	}
+}
+
+// Named selects the entries of the named logger.
+func Named(name string) Filter {
+	return func(e Entry) bool {
+		return e.LoggerName == name
+	}
+}
+
+// HasField selects the entries with the field, fields added with Logger.With are checked too.
+func HasField(key string) Filter {
+	return func(e Entry) bool {
+		_, ok := e.ContextMap()[key]
+		return ok
+	}
+}
+
+// Field selects the entries with the field equal to value, the value is compared in its encoded form,
+// so Field("attempt", 3) matches zap.Int("attempt", 3) and errors are matched by their text.
+func Field(key string, value any) Filter {
+	enc := zapcore.NewMapObjectEncoder()
+	if err, ok := value.(error); ok {
+		zap.NamedError(key, err).AddTo(enc)
+	} else {
+		zap.Any(key, value).AddTo(enc)
+	}
+	want := enc.Fields[key]
+
+	return func(e Entry) bool {
+		got, ok := e.ContextMap()[key]
+		return ok && reflect.DeepEqual(got, want)
+	}
+}
+
+func zapLevel(lvl logger.Level) zapcore.Level {
+	out, err := zapcore.ParseLevel(lvl.String())
+	if err != nil {
+		panic("invalid level: " + err.Error())
+	}
+	return out
+}
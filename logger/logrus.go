@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"io"
+
+	"github.com/sirupsen/logrus"
+	"go.uber.org/zap"
+)
+
+// LogrusHook returns the hook writing logrus entries through the logger.
+func (l *Logger) LogrusHook() logrus.Hook {
+	return &logrusHook{l: l}
+}
+
+// Logrus returns logrus logger which writes only through the logger, its own output is discarded
+// and the level is checked by the logger.
+func (l *Logger) Logrus() *logrus.Logger {
+	out := logrus.New()
+	out.SetOutput(io.Discard)
+	out.SetLevel(logrus.TraceLevel)
+	out.AddHook(l.LogrusHook())
+	return out
+}
+
+type logrusHook struct {
+	l *Logger
+}
+
+func (h *logrusHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *logrusHook) Fire(entry *logrus.Entry) error {
+	lvl := fromLogrusLevel(entry.Level)
+	if !h.l.enabled(lvl) {
+		return nil
+	}
+
+	fields := make([]zap.Field, 0, len(entry.Data))
+	for key, value := range entry.Data {
+		if err, ok := value.(error); ok {
+			fields = append(fields, zap.NamedError(key, err))
+			continue
+		}
+		fields = append(fields, zap.Any(key, value))
+	}
+
+	var pc uintptr
+	if entry.Caller != nil {
+		pc = entry.Caller.PC
+	}
+
+	h.l.write(entry.Context, lvl, pc, entry.Message, fields)
+	return nil
+}
+
+func fromLogrusLevel(lvl logrus.Level) Level {
+	switch lvl {
+	case logrus.PanicLevel:
+		return LevelPanic
+	case logrus.FatalLevel:
+		return LevelFatal
+	case logrus.ErrorLevel:
+		return LevelError
+	case logrus.WarnLevel:
+		return LevelWarn
+	case logrus.InfoLevel:
+		return LevelInfo
+	default:
+		return LevelDebug
+	}
+}
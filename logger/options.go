@@ -1,10 +1,30 @@
 package logger
 
-import "go.uber.org/zap"
+import (
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
 
 type options struct {
-	level   Level
-	senders []Sender
+	level        Level
+	senders      []senderConfig
+	extractors   []ContextExtractor
+	encoding     Encoding
+	sinks        []Sink
+	sampling     *sampling
+	staticFields []zap.Field
+	redactKeys   []string
+	redactRules  []RedactRule
+	cores        []zapcore.Core
+}
+
+type sampling struct {
+	tick       time.Duration
+	first      int
+	thereafter int
 }
 type Opt func(opt *options)
 
@@ -17,10 +37,105 @@ func WithLevel(level Level) Opt {
 	}
 }
 
-type Sender func(lvl Level, msg string, fields ...zap.Field)
+// WithEncoding sets the format of the log lines, json by default.
+func WithEncoding(encoding Encoding) Opt {
+	return func(o *options) {
+		if !encoding.IsValid() {
+			panic("invalid encoding")
+		}
+		o.encoding = encoding
+	}
+}
+
+// WithSinks sets the destinations of the log lines, stderr by default.
+func WithSinks(sinks ...Sink) Opt {
+	return func(o *options) {
+		o.sinks = append(o.sinks, sinks...)
+	}
+}
+
+// WithSampling logs the first entries with the same level and message during the tick,
+// and then every thereafter-th entry.
+func WithSampling(tick time.Duration, first, thereafter int) Opt {
+	return func(o *options) {
+		o.sampling = &sampling{tick: tick, first: first, thereafter: thereafter}
+	}
+}
+
+// WithServiceInfo adds the service, version and host fields to every log line.
+func WithServiceInfo(service, version string) Opt {
+	return func(o *options) {
+		host, _ := os.Hostname()
+		o.staticFields = append(o.staticFields,
+			zap.String("service", service),
+			zap.String("version", version),
+			zap.String("host", host),
+		)
+	}
+}
+
+// WithCore tees the log lines to the core, the levels, redaction and sampling of the logger are applied.
+func WithCore(core zapcore.Core) Opt {
+	return func(o *options) {
+		o.cores = append(o.cores, core)
+	}
+}
+
+// WithStaticFields adds the fields to every log line.
+func WithStaticFields(fields ...zap.Field) Opt {
+	return func(o *options) {
+		o.staticFields = append(o.staticFields, fields...)
+	}
+}
+
+// WithRedactKeys masks the fields with the keys, the keys are case-insensitive and the keys of nested objects are checked too.
+func WithRedactKeys(keys ...string) Opt {
+	return func(o *options) {
+		o.redactKeys = append(o.redactKeys, keys...)
+	}
+}
+
+// WithRedactRules applies the rules to the message and the string values of the fields.
+func WithRedactRules(rules ...RedactRule) Opt {
+	return func(o *options) {
+		o.redactRules = append(o.redactRules, rules...)
+	}
+}
+
+// WithDefaultRedaction masks DefaultRedactKeys, card numbers, bearer and telegram bot tokens.
+func WithDefaultRedaction() Opt {
+	return func(o *options) {
+		o.redactKeys = append(o.redactKeys, DefaultRedactKeys...)
+		o.redactRules = append(o.redactRules, RedactCardNumbers(), RedactBearerTokens(), RedactTelegramTokens())
+	}
+}
+
+type senderConfig struct {
+	send BatchSender
+	opts []SenderOpt
+}
+
+// WithSender adds the sender fed by its own bounded queue, the entries are sent one by one
+// unless WithBatching is set.
+func WithSender(sender Sender, opts ...SenderOpt) Opt {
+	return WithBatchSender(func(entries []Entry) {
+		for i := range entries {
+			sender(entries[i].Level, entries[i].Message, entries[i].Fields...)
+		}
+	}, opts...)
+}
+
+// WithBatchSender adds the sender which receives the accumulated entries at once, see WithBatching.
+func WithBatchSender(sender BatchSender, opts ...SenderOpt) Opt {
+	return func(o *options) {
+		o.senders = append(o.senders, senderConfig{send: sender, opts: opts})
+	}
+}
 
-func WithSender(sender Sender) Opt {
+// WithContextExtractor adds fields taken from the context to every *Ctx log call,
+// trace id, request id, grpc method and username are extracted by default.
+func WithContextExtractor(extractor ContextExtractor) Opt {
 	return func(o *options) {
-		o.senders = append(o.senders, sender)
+		o.extractors = append(o.extractors, extractor)
 	}
 }
@@ -0,0 +1,221 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const masked = "[masked]"
+
+// RedactRule replaces the sensitive parts of the value.
+type RedactRule func(value string) string
+
+var (
+	bearerRe   = regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9\-._~+/]+=*`)
+	tgTokenRe  = regexp.MustCompile(`bot\d{5,}:[A-Za-z0-9_-]{30,}`)
+	cardLikeRe = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+)
+
+// DefaultRedactKeys are the keys masked by WithDefaultRedaction.
+var DefaultRedactKeys = []string{
+	"password", "passwd", "secret", "token", "access_token", "refresh_token",
+	"authorization", "api_key", "apikey", "cookie", "set_cookie",
+}
+
+// RedactRegexp replaces the matches of re with replacement, it could refer the groups like regexp.ReplaceAllString.
+func RedactRegexp(re *regexp.Regexp, replacement string) RedactRule {
+	return func(value string) string {
+		return re.ReplaceAllString(value, replacement)
+	}
+}
+
+// RedactBearerTokens masks the tokens in "Bearer <token>".
+func RedactBearerTokens() RedactRule {
+	return RedactRegexp(bearerRe, "${1}"+masked)
+}
+
+// RedactTelegramTokens masks the bot tokens, e.g. in the telegram api urls.
+func RedactTelegramTokens() RedactRule {
+	return RedactRegexp(tgTokenRe, "bot"+masked)
+}
+
+// RedactCardNumbers masks the sequences of 13-19 digits passing the Luhn check.
+func RedactCardNumbers() RedactRule {
+	return func(value string) string {
+		return cardLikeRe.ReplaceAllStringFunc(value, func(match string) string {
+			if !luhn(match) {
+				return match
+			}
+			return masked
+		})
+	}
+}
+
+func luhn(number string) bool {
+	var sum, count int
+	for i := len(number) - 1; i >= 0; i-- {
+		c := number[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+
+		d := int(c - '0')
+		if count%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		count++
+	}
+	return sum%10 == 0
+}
+
+// redactor masks the fields by key and the values by rules before they reach cores and senders.
+type redactor struct {
+	keys  map[string]struct{}
+	rules []RedactRule
+}
+
+func newRedactor(keys []string, rules []RedactRule) *redactor {
+	if len(keys) == 0 && len(rules) == 0 {
+		return nil
+	}
+
+	r := &redactor{keys: make(map[string]struct{}, len(keys)), rules: rules}
+	for _, key := range keys {
+		r.keys[normalizeKey(key)] = struct{}{}
+	}
+	return r
+}
+
+func normalizeKey(key string) string {
+	return strings.ReplaceAll(strings.ToLower(key), "-", "_")
+}
+
+func (r *redactor) sensitive(key string) bool {
+	_, ok := r.keys[normalizeKey(key)]
+	return ok
+}
+
+func (r *redactor) value(value string) string {
+	for _, rule := range r.rules {
+		value = rule(value)
+	}
+	return value
+}
+
+func (r *redactor) fields(fields []zap.Field) []zap.Field {
+	if r == nil || len(fields) == 0 {
+		return fields
+	}
+
+	out := make([]zap.Field, len(fields))
+	for i := range fields {
+		out[i] = r.field(fields[i])
+	}
+	return out
+}
+
+func (r *redactor) field(f zap.Field) zap.Field {
+	if r.sensitive(f.Key) {
+		return zap.String(f.Key, masked)
+	}
+
+	switch f.Type {
+	case zapcore.StringType:
+		f.String = r.value(f.String)
+	case zapcore.ByteStringType, zapcore.BinaryType:
+		if b, ok := f.Interface.([]byte); ok {
+			return r.changed(f, string(b))
+		}
+	case zapcore.ErrorType:
+		if err, ok := f.Interface.(error); ok && err != nil {
+			return r.changed(f, err.Error())
+		}
+	case zapcore.StringerType:
+		if s, ok := f.Interface.(fmt.Stringer); ok {
+			return r.changed(f, s.String())
+		}
+	case zapcore.ReflectType, zapcore.ArrayMarshalerType, zapcore.ObjectMarshalerType:
+		return r.nested(f)
+	}
+	return f
+}
+
+// changed replaces the field with the string only when the rules changed its value.
+func (r *redactor) changed(f zap.Field, value string) zap.Field {
+	if out := r.value(value); out != value {
+		return zap.String(f.Key, out)
+	}
+	return f
+}
+
+// nested walks the objects and arrays through their json form, so the keys of the nested maps are masked too.
+func (r *redactor) nested(f zap.Field) zap.Field {
+	enc := zapcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+
+	raw, err := json.Marshal(enc.Fields[f.Key])
+	if err != nil {
+		return f
+	}
+
+	var v any
+	if err = json.Unmarshal(raw, &v); err != nil {
+		return f
+	}
+	return zap.Any(f.Key, r.walk(v))
+}
+
+func (r *redactor) walk(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for key, item := range val {
+			if r.sensitive(key) {
+				val[key] = masked
+				continue
+			}
+			val[key] = r.walk(item)
+		}
+		return val
+	case []any:
+		for i := range val {
+			val[i] = r.walk(val[i])
+		}
+		return val
+	case string:
+		return r.value(val)
+	default:
+		return v
+	}
+}
+
+// redactCore masks the fields and the message before they are encoded.
+type redactCore struct {
+	zapcore.Core
+	r *redactor
+}
+
+func (c *redactCore) With(fields []zap.Field) zapcore.Core {
+	return &redactCore{Core: c.Core.With(c.r.fields(fields)), r: c.r}
+}
+
+func (c *redactCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	// the wrapped core decides by itself, it could have its own level or sampling
+	if c.Core.Check(ent, nil) != nil {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *redactCore) Write(ent zapcore.Entry, fields []zap.Field) error {
+	ent.Message = c.r.value(ent.Message)
+	return c.Core.Write(ent, c.r.fields(fields))
+}
@@ -0,0 +1,277 @@
+package logger
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tarmalonchik/golibs/trace"
+)
+
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+type rotateOptions struct {
+	maxSize    int64
+	every      time.Duration
+	maxBackups int
+	compress   bool
+}
+
+type RotateOpt func(o *rotateOptions)
+
+// WithMaxSize rotates the file when it grows over the size in megabytes.
+func WithMaxSize(megabytes int) RotateOpt {
+	return func(o *rotateOptions) {
+		o.maxSize = int64(megabytes) * 1024 * 1024
+	}
+}
+
+// WithRotateEvery rotates the file when it is older than d.
+func WithRotateEvery(d time.Duration) RotateOpt {
+	return func(o *rotateOptions) {
+		o.every = d
+	}
+}
+
+// WithMaxBackups removes the oldest rotated files over the count, all of them are kept by default.
+func WithMaxBackups(count int) RotateOpt {
+	return func(o *rotateOptions) {
+		o.maxBackups = count
+	}
+}
+
+// WithCompress gzips the rotated files.
+func WithCompress() RotateOpt {
+	return func(o *rotateOptions) {
+		o.compress = true
+	}
+}
+
+type rotatingFile struct {
+	path string
+	o    rotateOptions
+	now  func() time.Time
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+
+	wg        sync.WaitGroup
+	cleanupMu sync.Mutex // serializes compression and removal of the backups
+}
+
+func newRotatingFile(path string, opts ...RotateOpt) (*rotatingFile, error) {
+	f := &rotatingFile{path: path, now: time.Now}
+	for _, opt := range opts {
+		opt(&f.o)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, trace.FuncNameWithErrorMsg(err, "create log dir")
+	}
+	if err := f.open(); err != nil {
+		return nil, trace.FuncNameWithError(err)
+	}
+	return f, nil
+}
+
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	var rotateErr error
+	if f.shouldRotate(len(p)) {
+		// the failed rotation is reported, but the entry is still written to the current file
+		if rotateErr = f.rotate(); rotateErr != nil && f.file == nil {
+			return 0, trace.FuncNameWithError(rotateErr)
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	if err == nil && rotateErr != nil {
+		err = trace.FuncNameWithError(rotateErr)
+	}
+	return n, err
+}
+
+func (f *rotatingFile) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	return f.file.Sync()
+}
+
+// Close closes the file and waits for the compression of the rotated files.
+func (f *rotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	defer f.wg.Wait()
+
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+func (f *rotatingFile) shouldRotate(size int) bool {
+	if f.size == 0 {
+		return false
+	}
+	if f.o.maxSize > 0 && f.size+int64(size) > f.o.maxSize {
+		return true
+	}
+	return f.o.every > 0 && f.now().Sub(f.openedAt) >= f.o.every
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return trace.FuncNameWithErrorMsg(err, "open log file")
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return trace.FuncNameWithErrorMsg(err, "stat log file")
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.openedAt = f.now()
+	return nil
+}
+
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return trace.FuncNameWithErrorMsg(err, "close log file")
+	}
+	f.file = nil
+
+	backup := f.backupName(f.now())
+	if err := os.Rename(f.path, backup); err != nil {
+		// keep logging to the same file
+		if openErr := f.open(); openErr != nil {
+			return trace.FuncNameWithError(errors.Join(err, openErr))
+		}
+		return trace.FuncNameWithErrorMsg(err, "rename log file")
+	}
+
+	if err := f.open(); err != nil {
+		return trace.FuncNameWithError(err)
+	}
+
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+
+		f.cleanupMu.Lock()
+		defer f.cleanupMu.Unlock()
+
+		if f.o.compress {
+			_ = compressFile(backup)
+		}
+		f.removeOldBackups()
+	}()
+	return nil
+}
+
+// backupName returns app-2024-01-02T15-04-05.000.log for app.log, the time is shifted when the name is taken.
+func (f *rotatingFile) backupName(t time.Time) string {
+	ext := filepath.Ext(f.path)
+	for {
+		name := strings.TrimSuffix(f.path, ext) + "-" + t.UTC().Format(backupTimeFormat) + ext
+		if !fileExists(name) && !fileExists(name+".gz") {
+			return name
+		}
+		t = t.Add(time.Millisecond)
+	}
+}
+
+func (f *rotatingFile) isBackup(name string) bool {
+	ext := filepath.Ext(f.path)
+
+	stamp, ok := strings.CutPrefix(strings.TrimSuffix(name, ".gz"), strings.TrimSuffix(f.path, ext)+"-")
+	if !ok {
+		return false
+	}
+	if stamp, ok = strings.CutSuffix(stamp, ext); !ok {
+		return false
+	}
+
+	_, err := time.Parse(backupTimeFormat, stamp)
+	return err == nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func (f *rotatingFile) removeOldBackups() {
+	if f.o.maxBackups <= 0 {
+		return
+	}
+
+	ext := filepath.Ext(f.path)
+	matches, err := filepath.Glob(strings.TrimSuffix(f.path, ext) + "-*" + ext + "*")
+	if err != nil {
+		return
+	}
+	// the glob matches the files of the other sinks too, e.g. app-worker.log for app.log
+	backups := slices.DeleteFunc(matches, func(name string) bool {
+		return !f.isBackup(name)
+	})
+
+	// the time in the names is sortable, so the newest backups are at the end
+	slices.Sort(backups)
+	for len(backups) > f.o.maxBackups {
+		_ = os.Remove(backups[0])
+		backups = backups[1:]
+	}
+}
+
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return trace.FuncNameWithErrorMsg(err, "open backup")
+	}
+	defer func() { _ = src.Close() }()
+
+	dst, err := os.Create(path + ".gz")
+	if err != nil {
+		return trace.FuncNameWithErrorMsg(err, "create gzip")
+	}
+
+	gz := gzip.NewWriter(dst)
+	if _, err = io.Copy(gz, src); err != nil {
+		_ = dst.Close()
+		return trace.FuncNameWithErrorMsg(err, "compress backup")
+	}
+	if err = gz.Close(); err != nil {
+		_ = dst.Close()
+		return trace.FuncNameWithErrorMsg(err, "close gzip")
+	}
+	if err = dst.Close(); err != nil {
+		return trace.FuncNameWithErrorMsg(err, "close gzip file")
+	}
+
+	return os.Remove(path)
+}
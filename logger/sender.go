@@ -0,0 +1,251 @@
+//go:generate go-enum -f=$GOFILE --nocase --values
+package logger
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// OverflowPolicy
+// ENUM(
+// drop
+// block
+// )
+type OverflowPolicy string
+
+type Sender func(lvl Level, msg string, fields ...zap.Field)
+
+// BatchSender receives the entries accumulated by the sender queue.
+// The slice is reused for the next batch after BatchSender returns, copy it to keep the entries.
+type BatchSender func(entries []Entry)
+
+type Entry struct {
+	Time    time.Time
+	Level   Level
+	Message string
+	Fields  []zap.Field
+}
+
+type senderOptions struct {
+	minLevel      Level
+	queueSize     int
+	batchSize     int
+	flushInterval time.Duration
+	overflow      OverflowPolicy
+}
+
+type SenderOpt func(o *senderOptions)
+
+// WithMinLevel skips the entries less severe than lvl.
+func WithMinLevel(lvl Level) SenderOpt {
+	return func(o *senderOptions) {
+		if !lvl.IsValid() {
+			panic("invalid level")
+		}
+		o.minLevel = lvl
+	}
+}
+
+// WithQueueSize sets the count of entries waiting for the sender, 1024 by default.
+func WithQueueSize(size int) SenderOpt {
+	return func(o *senderOptions) {
+		if size > 0 {
+			o.queueSize = size
+		}
+	}
+}
+
+// WithBatching makes the sender receive up to size entries at once,
+// the incomplete batch is sent after interval.
+func WithBatching(size int, interval time.Duration) SenderOpt {
+	return func(o *senderOptions) {
+		if size > 0 {
+			o.batchSize = size
+		}
+		if interval > 0 {
+			o.flushInterval = interval
+		}
+	}
+}
+
+// WithOverflowPolicy defines what happens with the log call when the queue is full,
+// the entry is dropped by default.
+func WithOverflowPolicy(policy OverflowPolicy) SenderOpt {
+	return func(o *senderOptions) {
+		if !policy.IsValid() {
+			panic("invalid overflow policy")
+		}
+		o.overflow = policy
+	}
+}
+
+func newSenderOptions(opts []SenderOpt) senderOptions {
+	o := senderOptions{
+		minLevel:      LevelDebug,
+		queueSize:     1024,
+		batchSize:     1,
+		flushInterval: time.Second,
+		overflow:      OverflowPolicyDrop,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+type senderQueue struct {
+	o       senderOptions
+	send    BatchSender
+	entries chan Entry
+	closing chan struct{}
+	done    chan struct{}
+	dropped atomic.Uint64
+}
+
+func newSenderQueue(send BatchSender, o senderOptions) *senderQueue {
+	q := &senderQueue{
+		o:       o,
+		send:    send,
+		entries: make(chan Entry, o.queueSize),
+		closing: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+func (q *senderQueue) enabled(lvl Level) bool {
+	return toZapLevel(lvl) >= toZapLevel(q.o.minLevel)
+}
+
+// push queues the entry, with the block policy it waits for the free slot until ctx is done or the queue is closed.
+func (q *senderQueue) push(ctx context.Context, e Entry) {
+	select {
+	case q.entries <- e:
+		return
+	default:
+	}
+
+	if q.o.overflow == OverflowPolicyBlock {
+		select {
+		case q.entries <- e:
+			return
+		case <-q.closing:
+		case <-ctx.Done():
+		}
+	}
+	q.dropped.Add(1)
+}
+
+func (q *senderQueue) run() {
+	defer close(q.done)
+
+	ticker := time.NewTicker(q.o.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Entry, 0, q.o.batchSize)
+	for {
+		select {
+		case e := <-q.entries:
+			batch = q.add(batch, e)
+		case <-ticker.C:
+			batch = q.flush(batch)
+		case <-q.closing:
+			q.drain(batch)
+			return
+		}
+	}
+}
+
+func (q *senderQueue) add(batch []Entry, e Entry) []Entry {
+	batch = append(batch, e)
+	if len(batch) >= q.o.batchSize {
+		return q.flush(batch)
+	}
+	return batch
+}
+
+// drain sends the entries queued before the close.
+func (q *senderQueue) drain(batch []Entry) {
+	for {
+		select {
+		case e := <-q.entries:
+			batch = q.add(batch, e)
+		default:
+			q.flush(batch)
+			return
+		}
+	}
+}
+
+func (q *senderQueue) flush(batch []Entry) []Entry {
+	if len(batch) == 0 {
+		return batch
+	}
+
+	defer func() {
+		// the broken sender should not stop the queue
+		_ = recover()
+	}()
+	q.send(batch)
+
+	return batch[:0]
+}
+
+// pipeline feeds the senders, it is shared by the logger and its children.
+type pipeline struct {
+	closeOnce sync.Once
+	closed    atomic.Bool
+	queues    []*senderQueue
+}
+
+func newPipeline(senders []senderConfig) *pipeline {
+	p := &pipeline{}
+	for _, s := range senders {
+		p.queues = append(p.queues, newSenderQueue(s.send, newSenderOptions(s.opts)))
+	}
+	return p
+}
+
+func (p *pipeline) push(ctx context.Context, e Entry) {
+	if p.closed.Load() {
+		return
+	}
+
+	for _, q := range p.queues {
+		if q.enabled(e.Level) {
+			q.push(ctx, e)
+		}
+	}
+}
+
+// close stops accepting entries and waits until the queued ones are sent.
+func (p *pipeline) close(ctx context.Context) error {
+	p.closeOnce.Do(func() {
+		p.closed.Store(true)
+		for _, q := range p.queues {
+			close(q.closing)
+		}
+	})
+
+	for _, q := range p.queues {
+		select {
+		case <-q.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (p *pipeline) dropped() uint64 {
+	var out uint64
+	for _, q := range p.queues {
+		out += q.dropped.Load()
+	}
+	return out
+}
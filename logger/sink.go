@@ -0,0 +1,37 @@
+package logger
+
+import (
+	"io"
+	"os"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Sink opens the destination of the log lines.
+type Sink func() (zapcore.WriteSyncer, error)
+
+func StdoutSink() Sink {
+	return func() (zapcore.WriteSyncer, error) {
+		return zapcore.Lock(os.Stdout), nil
+	}
+}
+
+func StderrSink() Sink {
+	return func() (zapcore.WriteSyncer, error) {
+		return zapcore.Lock(os.Stderr), nil
+	}
+}
+
+// DiscardSink drops the log lines, it is useful with WithCore.
+func DiscardSink() Sink {
+	return func() (zapcore.WriteSyncer, error) {
+		return zapcore.AddSync(io.Discard), nil
+	}
+}
+
+// FileSink writes to the file, it is rotated according to the options.
+func FileSink(path string, opts ...RotateOpt) Sink {
+	return func() (zapcore.WriteSyncer, error) {
+		return newRotatingFile(path, opts...)
+	}
+}
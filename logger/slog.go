@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// SlogHandler returns slog.Handler writing through the logger, so slog users share its level, format and senders:
+//
+//	slog.SetDefault(slog.New(l.SlogHandler()))
+func (l *Logger) SlogHandler() slog.Handler {
+	return &slogHandler{l: l}
+}
+
+type slogHandler struct {
+	l      *Logger
+	fields []zap.Field
+	groups []string
+}
+
+func (h *slogHandler) Enabled(_ context.Context, lvl slog.Level) bool {
+	return h.l.enabled(fromSlogLevel(lvl))
+}
+
+func (h *slogHandler) Handle(ctx context.Context, rec slog.Record) error {
+	fields := make([]zap.Field, 0, len(h.fields)+rec.NumAttrs())
+	fields = append(fields, h.fields...)
+	rec.Attrs(func(attr slog.Attr) bool {
+		fields = appendSlogAttr(fields, h.prefix(), attr)
+		return true
+	})
+
+	h.l.write(ctx, fromSlogLevel(rec.Level), rec.PC, rec.Message, fields)
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := append([]zap.Field{}, h.fields...)
+	for _, attr := range attrs {
+		fields = appendSlogAttr(fields, h.prefix(), attr)
+	}
+	return &slogHandler{l: h.l, fields: fields, groups: h.groups}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &slogHandler{l: h.l, fields: h.fields, groups: append(append([]string{}, h.groups...), name)}
+}
+
+// prefix returns the groups joined with dots, the nested attributes are flattened to "group.key".
+func (h *slogHandler) prefix() string {
+	if len(h.groups) == 0 {
+		return ""
+	}
+	return strings.Join(h.groups, ".") + "."
+}
+
+func appendSlogAttr(fields []zap.Field, prefix string, attr slog.Attr) []zap.Field {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		if attr.Key != "" {
+			prefix += attr.Key + "."
+		}
+		for _, item := range attr.Value.Group() {
+			fields = appendSlogAttr(fields, prefix, item)
+		}
+		return fields
+	}
+
+	key := prefix + attr.Key
+	switch attr.Value.Kind() {
+	case slog.KindString:
+		return append(fields, zap.String(key, attr.Value.String()))
+	case slog.KindInt64:
+		return append(fields, zap.Int64(key, attr.Value.Int64()))
+	case slog.KindUint64:
+		return append(fields, zap.Uint64(key, attr.Value.Uint64()))
+	case slog.KindFloat64:
+		return append(fields, zap.Float64(key, attr.Value.Float64()))
+	case slog.KindBool:
+		return append(fields, zap.Bool(key, attr.Value.Bool()))
+	case slog.KindDuration:
+		return append(fields, zap.Duration(key, attr.Value.Duration()))
+	case slog.KindTime:
+		return append(fields, zap.Time(key, attr.Value.Time()))
+	default:
+		if err, ok := attr.Value.Any().(error); ok {
+			return append(fields, zap.NamedError(key, err))
+		}
+		return append(fields, zap.Any(key, attr.Value.Any()))
+	}
+}
+
+func fromSlogLevel(lvl slog.Level) Level {
+	switch {
+	case lvl >= slog.LevelError:
+		return LevelError
+	case lvl >= slog.LevelWarn:
+		return LevelWarn
+	case lvl >= slog.LevelInfo:
+		return LevelInfo
+	default:
+		return LevelDebug
+	}
+}
@@ -7,7 +7,7 @@ import (
 )
 
 type Config struct {
-	Debug bool `env:"DEBUG" envDefault:"false"`
+	Debug bool `env:"DEBUG" envDefault:"false" desc:"return the fixed address instead of asking ipify"`
 }
 
 func My(config Config) netip.Addr {
@@ -6,18 +6,18 @@ import (
 )
 
 type Config struct {
-	PgProto          string        `env:"POSTGRES_PROTO" envDefault:"tcp"`
-	PgURL            string        `env:"POSTGRES_URL" envDefault:""`
-	PgAddress        string        `env:"POSTGRES_ADDRESS" envDefault:"localhost"`
-	PgPort           string        `env:"POSTGRES_PORT" envDefault:""`
-	PgDB             string        `env:"POSTGRES_DB" envDefault:""`
-	PgUser           string        `env:"POSTGRES_USER" envDefault:""`
-	PgPassword       string        `env:"POSTGRES_PASS" envDefault:""`
-	PgSSLMode        string        `env:"POSTGRES_SSL_MODE" envDefault:"verify-full"`
-	PgMigrationsPath string        `env:"POSTGRES_MIGRATIONSPATH" envDefault:"file:migrations"`
-	PgTimeout        time.Duration `env:"POSTGRES_TIMEOUT" envDefault:"5s"`
-	PGMaxIdleConns   int           `env:"POSTGRES_MAX_IDLE_CONNS" envDefault:"10"`
-	PGMaxOpenConns   int           `env:"POSTGRES_MAX_OPEN_CONNS" envDefault:"15"`
+	PgProto          string        `env:"POSTGRES_PROTO" envDefault:"tcp" desc:"network of the connection"`
+	PgURL            string        `env:"POSTGRES_URL" envDefault:"" desc:"full DSN as url or key=value pairs, overrides the address, port, db, user and password"`
+	PgAddress        string        `env:"POSTGRES_ADDRESS" envDefault:"localhost" validate:"required_without=PgURL" desc:"host of the server"`
+	PgPort           string        `env:"POSTGRES_PORT" envDefault:"" validate:"required_without=PgURL,port" desc:"port of the server"`
+	PgDB             string        `env:"POSTGRES_DB" envDefault:"" desc:"database name"`
+	PgUser           string        `env:"POSTGRES_USER" envDefault:"" desc:"user name"`
+	PgPassword       string        `env:"POSTGRES_PASS" envDefault:"" desc:"user password"`
+	PgSSLMode        string        `env:"POSTGRES_SSL_MODE" envDefault:"verify-full" validate:"oneof=disable allow prefer require verify-ca verify-full" desc:"SSL mode of the connection"`
+	PgMigrationsPath string        `env:"POSTGRES_MIGRATIONSPATH" envDefault:"file:migrations" desc:"source of the migrations"`
+	PgTimeout        time.Duration `env:"POSTGRES_TIMEOUT" envDefault:"5s" desc:"max lifetime of the pooled connection"`
+	PGMaxIdleConns   int           `env:"POSTGRES_MAX_IDLE_CONNS" envDefault:"10" desc:"max idle connections in the pool"`
+	PGMaxOpenConns   int           `env:"POSTGRES_MAX_OPEN_CONNS" envDefault:"15" desc:"max open connections in the pool, 0 is unlimited"`
 }
 
 func (c *Config) GetPGMigrationsPath() string { return c.PgMigrationsPath }
@@ -20,6 +20,7 @@ type Client interface {
 	Del(ctx context.Context, key string)
 	Dec(ctx context.Context, key string) (int64, error)
 	GetValuesByPattern(ctx context.Context, pattern string) (out [][]byte, err error)
+	Close() error
 }
 
 type client struct {
@@ -138,6 +139,10 @@ func (c *client) GetValuesByPattern(ctx context.Context, pattern string) (out []
 	return resp, nil
 }
 
+func (c *client) Close() error {
+	return c.client.Close()
+}
+
 func (c *client) wrapKey(key string) string {
 	if c.conf.RedisKeyPrefix == "" {
 		return key
@@ -22,6 +22,9 @@ var _ Client = &ClientMock{}
 //			AddFunc: func(ctx context.Context, key string, value []byte, expiration time.Duration) error {
 //				panic("mock out the Add method")
 //			},
+//			CloseFunc: func() error {
+//				panic("mock out the Close method")
+//			},
 //			DecFunc: func(ctx context.Context, key string) (int64, error) {
 //				panic("mock out the Dec method")
 //			},
@@ -47,6 +50,9 @@ type ClientMock struct {
 	// AddFunc mocks the Add method.
 	AddFunc func(ctx context.Context, key string, value []byte, expiration time.Duration) error
 
+	// CloseFunc mocks the Close method.
+	CloseFunc func() error
+
 	// DecFunc mocks the Dec method.
 	DecFunc func(ctx context.Context, key string) (int64, error)
 
@@ -75,6 +81,9 @@ type ClientMock struct {
 			// Expiration is the expiration argument value.
 			Expiration time.Duration
 		}
+		// Close holds details about calls to the Close method.
+		Close []struct {
+		}
 		// Dec holds details about calls to the Dec method.
 		Dec []struct {
 			// Ctx is the ctx argument value.
@@ -112,6 +121,7 @@ type ClientMock struct {
 		}
 	}
 	lockAdd                sync.RWMutex
+	lockClose              sync.RWMutex
 	lockDec                sync.RWMutex
 	lockDel                sync.RWMutex
 	lockGet                sync.RWMutex
@@ -163,6 +173,33 @@ func (mock *ClientMock) AddCalls() []struct {
 	return calls
 }
 
+// Close calls CloseFunc.
+func (mock *ClientMock) Close() error {
+	if mock.CloseFunc == nil {
+		panic("ClientMock.CloseFunc: method is nil but Client.Close was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockClose.Lock()
+	mock.calls.Close = append(mock.calls.Close, callInfo)
+	mock.lockClose.Unlock()
+	return mock.CloseFunc()
+}
+
+// CloseCalls gets all the calls that were made to Close.
+// Check the length with:
+//
+//	len(mockedClient.CloseCalls())
+func (mock *ClientMock) CloseCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockClose.RLock()
+	calls = mock.calls.Close
+	mock.lockClose.RUnlock()
+	return calls
+}
+
 // Dec calls DecFunc.
 func (mock *ClientMock) Dec(ctx context.Context, key string) (int64, error) {
 	if mock.DecFunc == nil {
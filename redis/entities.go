@@ -10,9 +10,9 @@ var (
 )
 
 type Config struct {
-	RedisAddress   string `env:"REDIS_ADDRESS" envDefault:"127.0.0.1"`
-	RedisPort      string `env:"REDIS_PORT" envDefault:"6379"`
-	RedisPassword  string `env:"REDIS_PASSWORD" envDefault:""`
-	RedisKeyPrefix string `env:"REDIS_KEY_PREFIX,required"`
-	RedisEnableTLS bool   `env:"REDIS_ENABLE_TLS" envDefault:"true"`
+	RedisAddress   string `env:"REDIS_ADDRESS" envDefault:"127.0.0.1" validate:"required" desc:"host of the server"`
+	RedisPort      string `env:"REDIS_PORT" envDefault:"6379" validate:"port" desc:"port of the server"`
+	RedisPassword  string `env:"REDIS_PASSWORD" envDefault:"" desc:"password"`
+	RedisKeyPrefix string `env:"REDIS_KEY_PREFIX,required" desc:"prefix of all keys"`
+	RedisEnableTLS bool   `env:"REDIS_ENABLE_TLS" envDefault:"true" desc:"connect with TLS"`
 }
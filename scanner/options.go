@@ -0,0 +1,29 @@
+package scanner
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/tarmalonchik/golibs/logger"
+)
+
+type Opt func(o *options)
+
+type options struct {
+	log *slog.Logger
+}
+
+func newDefaultOptions() *options {
+	return &options{
+		log: slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+			Level: slog.LevelInfo,
+		})),
+	}
+}
+
+// WithLogger makes the scanner log through the logger.
+func WithLogger(l *logger.Logger) Opt {
+	return func(o *options) {
+		o.log = slog.New(l.SlogHandler())
+	}
+}
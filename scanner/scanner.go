@@ -5,7 +5,6 @@ import (
 	"log/slog"
 	"net"
 	"net/netip"
-	"os"
 	"sort"
 	"strings"
 	"sync"
@@ -16,13 +15,18 @@ import (
 	"github.com/tarmalonchik/golibs/trace"
 )
 
-func RunAndFilter(addr netip.Addr, port, parallel int, timeout time.Duration) []string {
+func RunAndFilter(addr netip.Addr, port, parallel int, timeout time.Duration, opts ...Opt) []string {
+	o := newDefaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	prefix, err := addr.Prefix(24)
 	if err != nil {
 		panic(err)
 	}
 
-	out, err := runScanner(prefix.String(), port, parallel, timeout)
+	out, err := runScanner(o.log, prefix.String(), port, parallel, timeout)
 	if err != nil {
 		panic(err)
 	}
@@ -40,7 +44,7 @@ func RunAndFilter(addr netip.Addr, port, parallel int, timeout time.Duration) []
 	})
 }
 
-func runScanner(addr string, port, parallel int, timeout time.Duration) ([]string, error) {
+func runScanner(log *slog.Logger, addr string, port, parallel int, timeout time.Duration) ([]string, error) {
 	if _, _, err := net.ParseCIDR(addr); err != nil {
 		return nil, trace.FuncNameWithErrorMsg(err, "parsing CIDR")
 	}
@@ -49,13 +53,9 @@ func runScanner(addr string, port, parallel int, timeout time.Duration) ([]strin
 		return nil, trace.FuncNameWithError(errors.New("invalid inputs"))
 	}
 
-	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-		Level: slog.LevelInfo,
-	})))
-
 	var hostChan <-chan Host
 
-	hostChan = iterateAddr(addr)
+	hostChan = iterateAddr(log, addr)
 
 	outCh := make(chan string, 10000)
 
@@ -74,7 +74,7 @@ func runScanner(addr string, port, parallel int, timeout time.Duration) ([]strin
 	for i := 0; i < parallel; i++ {
 		go func() {
 			for ip := range hostChan {
-				scanTLS(ip, outCh, port, timeout)
+				scanTLS(log, ip, outCh, port, timeout)
 			}
 			wg.Done()
 		}()
@@ -82,13 +82,13 @@ func runScanner(addr string, port, parallel int, timeout time.Duration) ([]strin
 
 	t := time.Now()
 
-	slog.Info("Started all scanning threads", "time", t)
+	log.Info("Started all scanning threads", "time", t)
 
 	wg.Wait()
 
 	once()
 
-	slog.Info("Scanning completed", "time", time.Now(), "elapsed", time.Since(t).String())
+	log.Info("Scanning completed", "time", time.Now(), "elapsed", time.Since(t).String())
 
 	out := make([]string, 0, len(outCh))
 	for i := range outCh {
@@ -12,11 +12,11 @@ import (
 	"time"
 )
 
-func scanTLS(host Host, out chan<- string, port int, timeout time.Duration) {
+func scanTLS(log *slog.Logger, host Host, out chan<- string, port int, timeout time.Duration) {
 	if host.IP == nil {
 		ip, err := lookupIP(host.Origin)
 		if err != nil {
-			slog.Debug("Failed to get IP from the origin", "origin", host.Origin, "err", err)
+			log.Debug("Failed to get IP from the origin", "origin", host.Origin, "err", err)
 			return
 		}
 		host.IP = ip
@@ -24,7 +24,7 @@ func scanTLS(host Host, out chan<- string, port int, timeout time.Duration) {
 	hostPort := net.JoinHostPort(host.IP.String(), strconv.Itoa(port))
 	conn, err := net.DialTimeout("tcp", hostPort, timeout)
 	if err != nil {
-		slog.Debug("Cannot dial", "target", hostPort)
+		log.Debug("Cannot dial", "target", hostPort)
 		return
 	}
 
@@ -33,7 +33,7 @@ func scanTLS(host Host, out chan<- string, port int, timeout time.Duration) {
 	}()
 
 	if err = conn.SetDeadline(time.Now().Add(timeout)); err != nil {
-		slog.Error("Error setting deadline", "err", err)
+		log.Error("Error setting deadline", "err", err)
 		return
 	}
 
@@ -53,7 +53,7 @@ func scanTLS(host Host, out chan<- string, port int, timeout time.Duration) {
 	}()
 
 	if err = c.Handshake(); err != nil {
-		slog.Debug("TLS handshake failed", "target", hostPort)
+		log.Debug("TLS handshake failed", "target", hostPort)
 		return
 	}
 
@@ -62,12 +62,12 @@ func scanTLS(host Host, out chan<- string, port int, timeout time.Duration) {
 	domain := state.PeerCertificates[0].Subject.CommonName
 	issuers := strings.Join(state.PeerCertificates[0].Issuer.Organization, " | ")
 
-	log := slog.Info
+	logFunc := log.Info
 
 	feasible := true
 
 	if state.Version != tls.VersionTLS13 || alpn != "h2" || len(domain) == 0 || len(issuers) == 0 {
-		log = slog.Debug
+		logFunc = log.Debug
 		feasible = false
 	} else {
 		if isValidDomain(domain, timeout) {
@@ -76,7 +76,7 @@ func scanTLS(host Host, out chan<- string, port int, timeout time.Duration) {
 		}
 	}
 
-	log("Connected to target", "feasible", feasible, "ip", host.IP.String(),
+	logFunc("Connected to target", "feasible", feasible, "ip", host.IP.String(),
 		"origin", host.Origin,
 		"tls", tls.VersionName(state.Version), "alpn", alpn, "cert-domain", domain, "cert-issuer", issuers)
 }
@@ -29,7 +29,7 @@ type Host struct {
 	Type   HostType
 }
 
-func iterate(reader io.Reader) chan Host {
+func iterate(log *slog.Logger, reader io.Reader) chan Host {
 	scanner := bufio.NewScanner(reader)
 	hostChan := make(chan Host)
 	go func() {
@@ -53,7 +53,7 @@ func iterate(reader io.Reader) chan Host {
 				// ip cidr
 				p, err := netip.ParsePrefix(line)
 				if err != nil {
-					slog.Warn("Invalid cidr", "cidr", line, "err", err)
+					log.Warn("Invalid cidr", "cidr", line, "err", err)
 				}
 
 				if !p.Addr().Is4() {
@@ -88,10 +88,10 @@ func iterate(reader io.Reader) chan Host {
 				}
 				continue
 			}
-			slog.Warn("Not a valid IP, IP CIDR or domain", "line", line)
+			log.Warn("Not a valid IP, IP CIDR or domain", "line", line)
 		}
 		if err := scanner.Err(); err != nil && !errors.Is(err, io.EOF) {
-			slog.Error("Read file error", "err", err)
+			log.Error("Read file error", "err", err)
 		}
 	}()
 	return hostChan
@@ -102,12 +102,12 @@ func validateDomainName(domain string) bool {
 	return r.MatchString(domain)
 }
 
-func iterateAddr(addr string) <-chan Host {
+func iterateAddr(log *slog.Logger, addr string) <-chan Host {
 	hostChan := make(chan Host)
 
 	_, _, err := net.ParseCIDR(addr)
 	if err == nil {
-		return iterate(strings.NewReader(addr))
+		return iterate(log, strings.NewReader(addr))
 	}
 
 	ip := net.ParseIP(addr)
@@ -115,12 +115,12 @@ func iterateAddr(addr string) <-chan Host {
 		ip, err = lookupIP(addr)
 		if err != nil {
 			close(hostChan)
-			slog.Error("Not a valid IP, IP CIDR or domain", "addr", addr)
+			log.Error("Not a valid IP, IP CIDR or domain", "addr", addr)
 			return hostChan
 		}
 	}
 	go func() {
-		slog.Info("Enable infinite mode", "init", ip.String())
+		log.Info("Enable infinite mode", "init", ip.String())
 		lowIP := ip
 		highIP := ip
 		hostChan <- Host{
@@ -0,0 +1,207 @@
+package tgalert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/tarmalonchik/golibs/httpclient"
+	"github.com/tarmalonchik/golibs/logger"
+	"github.com/tarmalonchik/golibs/trace"
+)
+
+// Alerter forwards log entries to the telegram chat, it is used as the logger sender:
+//
+//	alerter := tgalert.NewAlerter(httpClient, conf)
+//	log := logger.NewLogger(logger.WithSender(alerter.Send, logger.WithMinLevel(logger.LevelError)))
+//
+// Identical messages are grouped within the window, the count of repeats is sent when the window expires.
+// Run should be started to send the repeats of the quiet groups. The http client must not log with the same alerter.
+type Alerter struct {
+	config     Config
+	httpClient httpclient.Client
+	levels     []logger.Level
+	window     time.Duration
+	rateCount  int
+	ratePeriod time.Duration
+	service    string
+	now        func() time.Time
+
+	mu     sync.Mutex
+	groups map[string]*group
+	sent   []time.Time
+}
+
+type group struct {
+	level   logger.Level
+	msg     string
+	started time.Time
+	count   int
+	sent    bool // the first entry is sent right away
+}
+
+func NewAlerter(httpClient httpclient.Client, config Config, opts ...Opt) *Alerter {
+	a := &Alerter{
+		config:     config,
+		httpClient: httpClient,
+		levels:     []logger.Level{logger.LevelError, logger.LevelFatal, logger.LevelPanic},
+		window:     time.Minute,
+		rateCount:  20,
+		ratePeriod: time.Minute,
+		now:        time.Now,
+		groups:     make(map[string]*group),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// Send implements logger.Sender.
+func (a *Alerter) Send(lvl logger.Level, msg string, fields ...zap.Field) {
+	if !slices.Contains(a.levels, lvl) {
+		return
+	}
+
+	texts := a.collect(lvl, msg, fields)
+	a.send(texts)
+}
+
+// Run sends the repeats of the expired groups until ctx is done, then sends the rest of them ignoring the rate limit.
+// It could be added to the launcher as the runner.
+func (a *Alerter) Run(ctx context.Context) error {
+	ticker := time.NewTicker(a.window / 4)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			a.send(a.expire(true))
+			return nil
+		case <-ticker.C:
+			a.send(a.expire(false))
+		}
+	}
+}
+
+// collect registers the entry and returns the messages which should be sent.
+func (a *Alerter) collect(lvl logger.Level, msg string, fields []zap.Field) []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	texts := a.expireLocked(false)
+
+	key := lvl.String() + "|" + msg
+	if g, ok := a.groups[key]; ok {
+		g.count++
+		return texts
+	}
+
+	now := a.now()
+	g := &group{level: lvl, msg: msg, started: now, count: 1}
+	a.groups[key] = g
+
+	// when the limit is reached the entry is reported with the repeats
+	if g.sent = a.allowLocked(now); g.sent {
+		texts = append(texts, a.format(lvl, msg, fields))
+	}
+	return texts
+}
+
+func (a *Alerter) expire(all bool) []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.expireLocked(all)
+}
+
+// expireLocked removes the expired groups and returns the messages about their repeats, a.mu should be held.
+func (a *Alerter) expireLocked(all bool) []string {
+	now := a.now()
+
+	var texts []string
+	for key, g := range a.groups {
+		if !all && now.Sub(g.started) < a.window {
+			continue
+		}
+		delete(a.groups, key)
+
+		if g.sent && g.count == 1 {
+			continue
+		}
+		if !all && !a.allowLocked(now) {
+			continue
+		}
+		texts = append(texts, a.formatRepeats(g))
+	}
+	return texts
+}
+
+// allowLocked checks the rate limit and reserves the message if it is allowed, a.mu should be held.
+func (a *Alerter) allowLocked(now time.Time) bool {
+	a.sent = slices.DeleteFunc(a.sent, func(t time.Time) bool {
+		return now.Sub(t) >= a.ratePeriod
+	})
+
+	if len(a.sent) >= a.rateCount {
+		return false
+	}
+	a.sent = append(a.sent, now)
+	return true
+}
+
+func (a *Alerter) send(texts []string) {
+	for _, text := range texts {
+		// the error could not be logged, it would be sent here again
+		_ = a.sendMessage(context.Background(), text)
+	}
+}
+
+func (a *Alerter) sendMessage(ctx context.Context, text string) error {
+	const (
+		method        = "/sendMessage"
+		requestMethod = http.MethodPost
+	)
+
+	u, err := url.Parse(a.config.TgBotBaseURL)
+	if err != nil {
+		return trace.FuncNameWithErrorMsg(err, "parsing base url")
+	}
+	u.Path += fmt.Sprintf(tokenTemp, a.config.TgBotToken) + method
+
+	body, err := json.Marshal(sendMessageRequest{
+		ChatID:                a.config.TgAlertChatID,
+		Text:                  text,
+		ParseMode:             "HTML",
+		DisableWebPagePreview: true,
+	})
+	if err != nil {
+		return trace.FuncNameWithErrorMsg(err, "marshal body")
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, requestMethod, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return trace.FuncNameWithErrorMsg(err, "create request")
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.httpClient.DoRequest(ctx, httpReq)
+	if err != nil {
+		return trace.FuncNameWithErrorMsg(err, "sending request")
+	}
+
+	if resp.StatusCode >= 400 {
+		return trace.FuncNameWithErrorMsg(errors.New("bad status code"), "bad status code")
+	}
+
+	return nil
+}
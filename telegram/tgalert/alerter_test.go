@@ -0,0 +1,69 @@
+package tgalert
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tarmalonchik/golibs/httpclient"
+	"github.com/tarmalonchik/golibs/logger"
+)
+
+func TestAlerter(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		texts []string
+	)
+
+	httpClient := &httpclient.ClientMock{
+		DoRequestFunc: func(ctx context.Context, req *http.Request) (*httpclient.Response, error) {
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+
+			var msg sendMessageRequest
+			require.NoError(t, json.Unmarshal(body, &msg))
+
+			mu.Lock()
+			texts = append(texts, msg.Text)
+			mu.Unlock()
+			return &httpclient.Response{StatusCode: http.StatusOK}, nil
+		},
+	}
+
+	now := time.Now()
+	a := NewAlerter(httpClient, Config{TgBotBaseURL: "https://api.telegram.org", TgAlertChatID: 1}, WithRateLimit(2, time.Hour))
+	a.now = func() time.Time { return now }
+
+	a.Send(logger.LevelInfo, "skipped")
+	a.Send(logger.LevelError, "db is down", zap.String("host", "<db>"), zap.String("stacktrace", "main.go:10"))
+	for range 16 {
+		a.Send(logger.LevelError, "db is down")
+	}
+	a.Send(logger.LevelError, "kafka is down")
+	a.Send(logger.LevelError, "limited")
+
+	now = now.Add(time.Minute)
+	require.NoError(t, a.Run(canceledContext()))
+
+	require.Equal(t, []string{
+		"<b>ERROR</b>\ndb is down\n\n<b>host</b>: <code>&lt;db&gt;</code>\n\n<pre>main.go:10</pre>",
+		"<b>ERROR</b>\nkafka is down",
+	}, texts[:2])
+	require.ElementsMatch(t, []string{
+		"<b>ERROR</b>\n<b>x17</b> in last minute: db is down",
+		"<b>ERROR</b>\n<b>x1</b> in last minute: limited",
+	}, texts[2:])
+}
+
+func canceledContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
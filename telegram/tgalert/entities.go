@@ -0,0 +1,16 @@
+package tgalert
+
+const tokenTemp = "/bot%s"
+
+type Config struct {
+	TgBotToken    string `env:"TELEGRAM_BOT_TOKEN,required" validate:"regex=^[0-9]+:[A-Za-z0-9_-]+$" desc:"bot token"`
+	TgBotBaseURL  string `env:"TELEGRAM_BOT_API_BASE_URL,required" validate:"url" desc:"bot api url, e.g. https://api.telegram.org"`
+	TgAlertChatID int64  `env:"TELEGRAM_ALERT_CHAT_ID,required" desc:"chat receiving the alerts"`
+}
+
+type sendMessageRequest struct {
+	ChatID                int64  `json:"chat_id"`
+	Text                  string `json:"text"`
+	ParseMode             string `json:"parse_mode"`
+	DisableWebPagePreview bool   `json:"disable_web_page_preview"`
+}
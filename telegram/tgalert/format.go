@@ -0,0 +1,74 @@
+package tgalert
+
+import (
+	"fmt"
+	"html"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/tarmalonchik/golibs/logger"
+)
+
+const (
+	stacktraceField = "stacktrace"
+	maxMessage      = 1000
+	maxFieldValue   = 300
+	maxStacktrace   = 2000
+)
+
+func (a *Alerter) format(lvl logger.Level, msg string, fields []zap.Field) string {
+	enc := zapcore.NewMapObjectEncoder()
+	for i := range fields {
+		fields[i].AddTo(enc)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(a.header(lvl))
+	sb.WriteString(html.EscapeString(cut(msg, maxMessage)))
+
+	stacktrace, _ := enc.Fields[stacktraceField].(string)
+	delete(enc.Fields, stacktraceField)
+
+	if len(enc.Fields) > 0 {
+		sb.WriteString("\n")
+	}
+	for _, key := range slices.Sorted(maps.Keys(enc.Fields)) {
+		fmt.Fprintf(&sb, "\n<b>%s</b>: <code>%s</code>", html.EscapeString(key), html.EscapeString(cut(fmt.Sprint(enc.Fields[key]), maxFieldValue)))
+	}
+
+	if stacktrace != "" {
+		fmt.Fprintf(&sb, "\n\n<pre>%s</pre>", html.EscapeString(cut(stacktrace, maxStacktrace)))
+	}
+
+	return sb.String()
+}
+
+func (a *Alerter) formatRepeats(g *group) string {
+	window := a.window.String()
+	if a.window == time.Minute {
+		window = "minute"
+	}
+	return fmt.Sprintf("%s<b>x%d</b> in last %s: %s", a.header(g.level), g.count, window, html.EscapeString(cut(g.msg, maxMessage)))
+}
+
+func (a *Alerter) header(lvl logger.Level) string {
+	header := "<b>" + strings.ToUpper(lvl.String()) + "</b>"
+	if a.service != "" {
+		header += " [" + html.EscapeString(a.service) + "]"
+	}
+	return header + "\n"
+}
+
+// cut limits the parts of the message, so it fits the telegram limit of 4096 characters.
+func cut(text string, size int) string {
+	runes := []rune(text)
+	if len(runes) <= size {
+		return text
+	}
+	return string(runes[:size]) + "..."
+}
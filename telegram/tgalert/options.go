@@ -0,0 +1,42 @@
+package tgalert
+
+import (
+	"time"
+
+	"github.com/tarmalonchik/golibs/logger"
+)
+
+type Opt func(a *Alerter)
+
+// WithLevels sets the levels forwarded to the chat, error, fatal and panic by default.
+func WithLevels(levels ...logger.Level) Opt {
+	return func(a *Alerter) {
+		a.levels = levels
+	}
+}
+
+// WithGroupWindow sets the window in which identical messages are grouped, one minute by default.
+func WithGroupWindow(window time.Duration) Opt {
+	return func(a *Alerter) {
+		if window > 0 {
+			a.window = window
+		}
+	}
+}
+
+// WithRateLimit sets the maximum count of messages sent to the chat per period, 20 per minute by default.
+func WithRateLimit(count int, period time.Duration) Opt {
+	return func(a *Alerter) {
+		if count > 0 && period > 0 {
+			a.rateCount = count
+			a.ratePeriod = period
+		}
+	}
+}
+
+// WithServiceName adds the name of the service to the header of every message.
+func WithServiceName(name string) Opt {
+	return func(a *Alerter) {
+		a.service = name
+	}
+}
@@ -7,8 +7,8 @@ const (
 )
 
 type Config struct {
-	TgBotToken   string `env:"TELEGRAM_BOT_TOKEN,required"`
-	TgBotBaseURL string `env:"TELEGRAM_BOT_API_BASE_URL,required"`
+	TgBotToken   string `env:"TELEGRAM_BOT_TOKEN,required" validate:"regex=^[0-9]+:[A-Za-z0-9_-]+$" desc:"bot token"`
+	TgBotBaseURL string `env:"TELEGRAM_BOT_API_BASE_URL,required" validate:"url" desc:"bot api url, e.g. https://api.telegram.org"`
 }
 
 type sendDocResponseBody struct {